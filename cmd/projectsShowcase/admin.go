@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"projectsShowcase/internal/domain/auth"
+	"strings"
+)
+
+const adminUsage = "usage: projectsShowcase admin add <login> viewer|reviewer|superadmin | admin passwd <login>"
+
+// runAdmin executes the admin subcommand.
+//
+// add creates an admin with the given role, passwd changes the password of an admin
+// and signs them out. The password is read from the first line of the standard input.
+func runAdmin(ctx context.Context, authService *auth.Service, args []string) error {
+	if len(args) < 2 {
+		return errors.New(adminUsage)
+	}
+
+	switch {
+	case args[0] == "add" && len(args) == 3:
+		password, err := readPassword()
+		if err != nil {
+			return err
+		}
+
+		id, err := authService.CreateAdmin(ctx, args[1], password, args[2])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("created admin %s (id %d) with role %s\n", args[1], id, args[2])
+	case args[0] == "passwd" && len(args) == 2:
+		password, err := readPassword()
+		if err != nil {
+			return err
+		}
+
+		if err := authService.ChangePassword(ctx, args[1], password); err != nil {
+			return err
+		}
+
+		fmt.Printf("changed password of admin %s\n", args[1])
+	default:
+		return errors.New(adminUsage)
+	}
+
+	return nil
+}
+
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "password: ")
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"projectsShowcase/internal/domain/spreadsheet"
+	"projectsShowcase/internal/lib/table"
+	"text/tabwriter"
+)
+
+const importUsage = "usage: projectsShowcase import [-dry-run] [-as <login>] <file.csv|file.xlsx>"
+
+// runImport executes the import subcommand.
+//
+// It imports the applications from a CSV or XLSX table like POST /admin/applications/import,
+// recording them as edited by the -as login. With -dry-run the table is only validated.
+func runImport(ctx context.Context, importer *spreadsheet.Importer, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	dryRun := flags.Bool("dry-run", false, "only validate the table")
+	importedBy := flags.String("as", "import", "login the applications are recorded as imported by")
+
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		return errors.New(importUsage)
+	}
+
+	path := flags.Arg(0)
+
+	format := table.FormatOf(path)
+	if format == "" {
+		return fmt.Errorf("%s is not a .csv or .xlsx file", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader, err := table.NewReader(format, file)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	report, err := importer.Import(ctx, reader, *importedBy, *dryRun)
+	if errors.Is(err, spreadsheet.ErrInvalid) {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ROW\tFIELD\tPROBLEM")
+		for _, rowErr := range report.Errors {
+			for _, fieldErr := range rowErr.Errors {
+				fmt.Fprintf(w, "%d\t%s\t%s\n", rowErr.Row, fieldErr.Field, fieldErr.Message)
+			}
+		}
+		if flushErr := w.Flush(); flushErr != nil {
+			return flushErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		fmt.Printf("%d application(s) are valid, nothing imported (dry run)\n", report.Rows)
+		return nil
+	}
+
+	fmt.Printf("imported %d application(s)\n", report.Imported)
+
+	return nil
+}
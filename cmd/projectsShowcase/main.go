@@ -2,20 +2,80 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"projectsShowcase/internal/blob"
+	"projectsShowcase/internal/blob/local"
+	"projectsShowcase/internal/blob/s3"
+	"projectsShowcase/internal/blobgc"
 	"projectsShowcase/internal/config"
+	"projectsShowcase/internal/domain/attachment"
+	"projectsShowcase/internal/domain/auth"
+	"projectsShowcase/internal/domain/form"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/domain/selfservice"
+	"projectsShowcase/internal/domain/spreadsheet"
+	"projectsShowcase/internal/domain/workflow"
+	"projectsShowcase/internal/health"
+	"projectsShowcase/internal/http-server/handlers/admin/login"
+	"projectsShowcase/internal/http-server/handlers/admin/logout"
+	"projectsShowcase/internal/http-server/handlers/application/export"
 	"projectsShowcase/internal/http-server/handlers/application/getAll"
 	"projectsShowcase/internal/http-server/handlers/application/getApproved"
+	"projectsShowcase/internal/http-server/handlers/application/getApprovedAttachment"
+	"projectsShowcase/internal/http-server/handlers/application/getApprovedByID"
+	"projectsShowcase/internal/http-server/handlers/application/getAttachment"
 	"projectsShowcase/internal/http-server/handlers/application/getByID"
+	"projectsShowcase/internal/http-server/handlers/application/getHistory"
+	"projectsShowcase/internal/http-server/handlers/application/getRevisionDiff"
+	"projectsShowcase/internal/http-server/handlers/application/getRevisions"
+	"projectsShowcase/internal/http-server/handlers/application/getSelf"
+	"projectsShowcase/internal/http-server/handlers/application/getTrash"
+	"projectsShowcase/internal/http-server/handlers/application/importApplications"
+	"projectsShowcase/internal/http-server/handlers/application/patch"
 	"projectsShowcase/internal/http-server/handlers/application/remove"
+	"projectsShowcase/internal/http-server/handlers/application/restore"
 	"projectsShowcase/internal/http-server/handlers/application/save"
+	"projectsShowcase/internal/http-server/handlers/application/updateSelf"
 	"projectsShowcase/internal/http-server/handlers/application/updateStatus"
+	"projectsShowcase/internal/http-server/handlers/dictionaries"
+	"projectsShowcase/internal/http-server/handlers/form/getFields"
+	"projectsShowcase/internal/http-server/handlers/form/getSchema"
+	"projectsShowcase/internal/http-server/handlers/form/saveField"
+	"projectsShowcase/internal/http-server/handlers/form/updateField"
+	"projectsShowcase/internal/http-server/handlers/health/live"
+	"projectsShowcase/internal/http-server/handlers/health/ready"
+	metricsHandler "projectsShowcase/internal/http-server/handlers/metrics"
+	"projectsShowcase/internal/http-server/handlers/tags/addSynonym"
+	getAllTags "projectsShowcase/internal/http-server/handlers/tags/getAll"
+	"projectsShowcase/internal/http-server/handlers/tags/getTags"
+	"projectsShowcase/internal/http-server/handlers/tags/merge"
+	"projectsShowcase/internal/http-server/handlers/tags/removeSynonym"
+	"projectsShowcase/internal/http-server/handlers/tags/rename"
+	authMiddleware "projectsShowcase/internal/http-server/middleware/auth"
+	"projectsShowcase/internal/http-server/middleware/contenttype"
 	"projectsShowcase/internal/http-server/middleware/logger"
+	metricsMiddleware "projectsShowcase/internal/http-server/middleware/metrics"
+	rateLimitMiddleware "projectsShowcase/internal/http-server/middleware/ratelimit"
+	tracingMiddleware "projectsShowcase/internal/http-server/middleware/tracing"
+	"projectsShowcase/internal/lib/captcha"
 	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/lib/mail"
+	"projectsShowcase/internal/lib/ratelimit"
+	"projectsShowcase/internal/metrics"
+	"projectsShowcase/internal/notification"
+	"projectsShowcase/internal/storage"
+	"projectsShowcase/internal/storage/postgres"
 	"projectsShowcase/internal/storage/sqlite"
+	"projectsShowcase/internal/tracing"
+	"projectsShowcase/internal/trash"
+	"sync"
 	"syscall"
 	"time"
 
@@ -26,10 +86,18 @@ import (
 
 const (
 	envLocal = "local"
+	envTest  = "test"
 	envDev   = "dev"
 	envProd  = "prod"
 )
 
+// Exit codes of the server, so that the orchestrator can tell a database problem from other failures.
+const (
+	exitFailure = 1
+	// exitStorage means the database can't be opened or its schema doesn't match the binary.
+	exitStorage = 3
+)
+
 func main() {
 	cfg := config.MustLoad()
 
@@ -39,41 +107,231 @@ func main() {
 	log.Info("initializing server", slog.String("address", cfg.Address))
 	log.Debug("logger debug mode enabled")
 
-	storage, err := sqlite.New(cfg.StoragePath)
+	shutdownTracing, err := setupTracing(cfg)
+	if err != nil {
+		log.Error("failed to initialize tracing", sl.Err(err))
+		os.Exit(exitFailure)
+	}
+
+	storage, err := setupStorage(cfg)
 	if err != nil {
 		log.Error("failed to initialize storage", sl.Err(err))
+		os.Exit(exitStorage)
+	}
+
+	appMetrics := metrics.New()
+	storage.SetOpObserver(appMetrics)
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := runMigrate(storage, os.Args[2:])
+		if closeErr := storage.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			log.Error("migration failed", sl.Err(err))
+			os.Exit(exitFailure)
+		}
+
+		return
+	}
+
+	if err := setupSchema(log, cfg, storage); err != nil {
+		log.Error("database schema is not up to date", sl.Err(err))
+		os.Exit(exitStorage)
+	}
+
+	authService := auth.New(storage, cfg.HTTPServer.SessionTTL)
+
+	if len(os.Args) > 1 && os.Args[1] == "admin" {
+		err := runAdmin(context.Background(), authService, os.Args[2:])
+		if closeErr := storage.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			log.Error("admin command failed", sl.Err(err))
+			os.Exit(exitFailure)
+		}
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "tags" {
+		err := runTags(context.Background(), storage, os.Args[2:])
+		if closeErr := storage.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			log.Error("tags command failed", sl.Err(err))
+			os.Exit(exitFailure)
+		}
+
+		return
+	}
+
+	importer := spreadsheet.NewImporter(storage)
+
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		err := runImport(context.Background(), importer, os.Args[2:])
+		if closeErr := storage.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			log.Error("import failed", sl.Err(err))
+			os.Exit(exitFailure)
+		}
+
+		return
+	}
+
+	if err := setupAdmin(context.Background(), log, cfg, authService); err != nil {
+		log.Error("failed to create the initial superadmin", sl.Err(err))
+		os.Exit(exitFailure)
+	}
+
+	selfService, err := setupSelfService(log, cfg, storage)
+	if err != nil {
+		log.Error("failed to initialize self-service", sl.Err(err))
+		os.Exit(exitFailure)
+	}
+
+	dispatcher, err := setupNotifications(log, cfg, storage, selfService)
+	if err != nil {
+		log.Error("failed to initialize notifications", sl.Err(err))
+		os.Exit(exitFailure)
+	}
+
+	blobs, err := setupBlobStore(cfg)
+	if err != nil {
+		log.Error("failed to initialize attachment storage", sl.Err(err))
+		os.Exit(exitFailure)
+	}
+
+	attachments := attachment.New(blobs, storage, cfg.Attachments.MaxFileSizeMB<<20, cfg.Attachments.MaxFiles, cfg.Attachments.PresignTTL)
+
+	// workers are the background jobs, stopped after the server.
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	if dispatcher != nil {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			dispatcher.Run(workersCtx)
+		}()
+	}
+
+	if cfg.Trash.RetentionDays > 0 {
+		purger := trash.New(log, storage, time.Duration(cfg.Trash.RetentionDays)*24*time.Hour, cfg.Trash.PurgeInterval)
+
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			purger.Run(workersCtx)
+		}()
+	}
+
+	collector := blobgc.New(log, storage, blobs, cfg.Attachments.GCGracePeriod, cfg.Attachments.GCInterval)
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		collector.Run(workersCtx)
+	}()
+
+	statusWorkflow := workflow.New(storage)
+	formService := form.New(storage)
+
+	checker, err := setupHealth(cfg, storage)
+	if err != nil {
+		log.Error("failed to initialize health checks", sl.Err(err))
+		os.Exit(exitFailure)
 	}
 
 	router := chi.NewRouter()
 
+	router.Use(tracingMiddleware.New(log, "/healthz", "/readyz", "/metrics"))
+
 	corsOptions := cors.Options{
-        AllowedOrigins:   []string{"http://localhost:3000"},
-        AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-        AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-        ExposedHeaders:   []string{"Link"},
-        AllowCredentials: true,
-        MaxAge:           300,
-       }
-       router.Use(cors.Handler(corsOptions))
+		AllowedOrigins:   []string{"http://localhost:3000"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-Match", "X-CSRF-Token"},
+		ExposedHeaders:   []string{"Link", "ETag"},
+		AllowCredentials: true,
+		MaxAge:           300,
+	}
+	router.Use(cors.Handler(corsOptions))
 
 	router.Use(middleware.RequestID)
 	//router.Use(middleware.Logger)
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.URLFormat)
 	router.Use(logger.New(log))
+	router.Use(metricsMiddleware.New(log, appMetrics))
+
+	appMetrics.RegisterApplications(log, storage)
+	router.Get("/metrics", metricsHandler.New(log, appMetrics.Registry, cfg.Metrics.Token))
+
+	router.Get("/healthz", live.New())
+	router.Get("/readyz", ready.New(log, checker))
 
-	router.Post("/applications", save.New(log, storage))
+	router.Get("/dictionaries", dictionaries.New(log))
+	router.Get("/form-schema", getSchema.New(log, formService))
+	router.Get("/tags", getTags.New(log, storage))
+
+	router.With(rateLimitMiddleware.New(log, ratelimit.New(cfg.RateLimit.IPEvery, cfg.RateLimit.IPBurst), rateLimitMiddleware.ByIP(cfg.RateLimit.TrustProxy))).
+		Post("/applications", save.New(log, storage, selfService, attachments, ratelimit.New(cfg.RateLimit.EmailEvery, cfg.RateLimit.EmailBurst), setupCaptcha(cfg), formService, appMetrics))
 	router.Get("/applications/approved", getApproved.New(log, storage))
-	router.Get("/applications/{id}", getByID.New(log, storage))
+	router.Get("/applications/self/{token}", getSelf.New(log, selfService))
+	router.Put("/applications/self/{token}", updateSelf.New(log, selfService, formService))
+	router.Get("/applications/{publicID}", getApprovedByID.New(log, storage))
+	router.Get("/applications/{publicID}/attachments/{attachmentID}", getApprovedAttachment.New(log, storage, attachments))
 
 	router.Route("/admin", func(r chi.Router) {
-		r.Use(middleware.BasicAuth("projects-showcase", map[string]string{
-			cfg.HTTPServer.User: cfg.HTTPServer.Password,
-		}))
+		r.Post("/login", login.New(log, authService, !cfg.HTTPServer.InsecureCookies))
+
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.New(log, authService))
+
+			r.Post("/logout", logout.New(log, authService, !cfg.HTTPServer.InsecureCookies))
+
+			r.Get("/applications", getAll.New(log, storage))
+			r.Get("/applications/export", export.New(log, storage, formService))
+			r.Get("/applications/{id}", getByID.New(log, storage))
+			r.Get("/applications/{id}/history", getHistory.New(log, storage))
+			r.Get("/applications/{id}/attachments/{attachmentID}", getAttachment.New(log, storage, attachments))
+			r.Get("/applications/{id}/revisions", getRevisions.New(log, storage))
+			r.Get("/applications/{id}/revisions/{n}/diff", getRevisionDiff.New(log, storage))
+			r.Get("/form-fields", getFields.New(log, formService))
+			r.Get("/tags", getAllTags.New(log, storage))
+
+			r.With(authMiddleware.RequireRole(models.RoleReviewer)).
+				Post("/applications/import", importApplications.New(log, importer, appMetrics))
+
+			// A JSON Merge Patch edits the application fields, a plain JSON body changes the status.
+			r.With(authMiddleware.RequireRole(models.RoleReviewer)).
+				Patch("/applications/{id}", contenttype.Route(map[string]http.Handler{
+					patch.MediaType: patch.New(log, storage, formService),
+				}, updateStatus.New(log, statusWorkflow)))
+
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware.RequireRole(models.RoleReviewer))
+
+				r.Put("/tags/{id}", rename.New(log, storage))
+				r.Post("/tags/{id}/merge", merge.New(log, storage))
+				r.Post("/tags/{id}/synonyms", addSynonym.New(log, storage))
+				r.Delete("/tags/{id}/synonyms/{name}", removeSynonym.New(log, storage))
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware.RequireRole(models.RoleSuperadmin))
 
-		r.Get("/applications", getAll.New(log, storage))
-		r.Patch("/applications/{id}", updateStatus.New(log, storage))
-		r.Delete("/applications/{id}", remove.New(log, storage))
+				r.Delete("/applications/{id}", remove.New(log, storage))
+				r.Get("/trash", getTrash.New(log, storage))
+				r.Post("/applications/{id}/restore", restore.New(log, storage))
+				r.Post("/form-fields", saveField.New(log, formService))
+				r.Put("/form-fields/{id}", updateField.New(log, formService))
+			})
+		})
 	})
 
 	log.Info("starting server", slog.String("address", cfg.Address))
@@ -90,8 +348,9 @@ func main() {
 	}
 
 	go func() {
-		if err := srv.ListenAndServe(); err != nil {
-			log.Error("failed to start server")
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("failed to start server", sl.Err(err))
+			os.Exit(exitFailure)
 		}
 	}()
 
@@ -110,7 +369,16 @@ func main() {
 		return
 	}
 
-	// TODO: close storage
+	stopWorkers()
+	workers.Wait()
+
+	if err := storage.Close(); err != nil {
+		log.Error("failed to close storage", sl.Err(err))
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error("failed to flush traces", sl.Err(err))
+	}
 
 	log.Info("server stopped")
 }
@@ -120,7 +388,7 @@ func main() {
 // The function takes an environment string as input and returns a pointer to a slog.Logger.
 // The logger is configured based on the environment:
 //
-// - If the environment is envLocal, a text handler with debug level is used.
+// - If the environment is envLocal or envTest, a text handler with debug level is used.
 //
 // - If the environment is envDev, a JSON handler with debug level is used.
 //
@@ -129,7 +397,7 @@ func setupLogger(env string) *slog.Logger {
 	var log *slog.Logger
 
 	switch env {
-	case envLocal:
+	case envLocal, envTest:
 		log = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 	case envDev:
 		log = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
@@ -139,3 +407,197 @@ func setupLogger(env string) *slog.Logger {
 
 	return log
 }
+
+// setupSchema applies pending migrations unless storage.skip_migrations is set
+// and makes sure the database schema matches the binary.
+func setupSchema(log *slog.Logger, cfg *config.Config, storage storage.Repository) error {
+	migrator, err := storage.Migrator()
+	if err != nil {
+		return err
+	}
+
+	if !cfg.Storage.SkipMigrations {
+		applied, err := migrator.Up()
+		if err != nil {
+			return err
+		}
+
+		log.Info("migrations applied", slog.Int("count", applied), slog.Int64("version", migrator.Latest()))
+	}
+
+	return migrator.Check()
+}
+
+// setupBlobStore returns the store of the attachment files chosen by attachments.driver.
+func setupBlobStore(cfg *config.Config) (blob.Store, error) {
+	switch cfg.Attachments.Driver {
+	case config.BlobDriverS3:
+		s, err := s3.New(s3.Options{
+			Endpoint:        cfg.Attachments.S3.Endpoint,
+			Region:          cfg.Attachments.S3.Region,
+			Bucket:          cfg.Attachments.S3.Bucket,
+			AccessKeyID:     cfg.Attachments.S3.AccessKeyID,
+			SecretAccessKey: cfg.Attachments.S3.SecretAccessKey,
+			Insecure:        cfg.Attachments.S3.Insecure,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	case config.BlobDriverLocal:
+		s, err := local.New(cfg.Attachments.Dir)
+		if err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unknown attachments driver: %q", cfg.Attachments.Driver)
+	}
+}
+
+// setupHealth returns the readiness checks: the database is reachable and up to date
+// and the local files have enough disk space.
+func setupHealth(cfg *config.Config, storage storage.Repository) (*health.Checker, error) {
+	migrator, err := storage.Migrator()
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []health.Check{
+		{Name: "database", Run: storage.Ping},
+		{Name: "migrations", Run: func(ctx context.Context) error { return migrator.Check() }},
+	}
+
+	minFree := cfg.Health.MinFreeDiskMB << 20
+
+	if cfg.Storage.Driver == config.StorageDriverSQLite {
+		checks = append(checks, health.Check{
+			Name: "disk:database",
+			Run:  health.DiskSpace(filepath.Dir(cfg.StoragePath), minFree),
+		})
+	}
+
+	if cfg.Attachments.Driver == config.BlobDriverLocal {
+		checks = append(checks, health.Check{
+			Name: "disk:attachments",
+			Run:  health.DiskSpace(cfg.Attachments.Dir, minFree),
+		})
+	}
+
+	return health.New(cfg.Health.Timeout, checks...), nil
+}
+
+// setupTracing installs the tracer provider configured by the tracing section and returns the function flushing it.
+// Without an exporter the spans are not recorded and the function does nothing.
+func setupTracing(cfg *config.Config) (func(context.Context) error, error) {
+	if cfg.Tracing.Exporter == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	return tracing.Setup(context.Background(), tracing.Options{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		ServiceName: cfg.Tracing.ServiceName,
+	})
+}
+
+// setupCaptcha returns the CAPTCHA verifier of the configured provider.
+func setupCaptcha(cfg *config.Config) save.CaptchaVerifier {
+	switch cfg.Captcha.Provider {
+	case "siteverify":
+		return captcha.NewSiteVerify(cfg.Captcha.VerifyURL, cfg.Captcha.Secret, cfg.Captcha.Timeout)
+	case "fake":
+		return captcha.Fake{}
+	default:
+		return captcha.None{}
+	}
+}
+
+// setupAdmin creates a superadmin from the http_server.user and http_server.password config keys
+// if there are no admins yet.
+func setupAdmin(ctx context.Context, log *slog.Logger, cfg *config.Config, authService *auth.Service) error {
+	if cfg.HTTPServer.User == "" || cfg.HTTPServer.Password == "" {
+		return nil
+	}
+
+	created, err := authService.Bootstrap(ctx, cfg.HTTPServer.User, cfg.HTTPServer.Password)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		log.Info("initial superadmin created", slog.String("login", cfg.HTTPServer.User))
+	}
+
+	return nil
+}
+
+// setupNotifications creates the dispatcher of the applicant notifications,
+// it returns nil if notifications are disabled.
+func setupNotifications(
+	log *slog.Logger,
+	cfg *config.Config,
+	storage storage.Repository,
+	links notification.LinkBuilder,
+) (*notification.Dispatcher, error) {
+	if !cfg.Notifications.Enabled {
+		log.Info("notifications are disabled")
+
+		return nil, nil
+	}
+
+	templates, err := notification.LoadTemplates(cfg.Notifications.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	smtp := cfg.Notifications.SMTP
+
+	sender, err := mail.NewSMTPSender(smtp.Host, smtp.Port, smtp.Username, smtp.Password, smtp.From, smtp.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return notification.New(log, storage, sender, templates, links, cfg.Notifications.PollInterval, cfg.Notifications.MaxAttempts), nil
+}
+
+// setupSelfService creates the service behind the applicant self-service links.
+func setupSelfService(log *slog.Logger, cfg *config.Config, storage storage.Repository) (*selfservice.Service, error) {
+	key := []byte(cfg.SelfService.Secret)
+	if len(key) == 0 {
+		log.Warn("self_service.secret is not set, self-service links will stop working after a restart")
+
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return selfservice.New(storage, key, cfg.SelfService.TokenTTL, cfg.SelfService.LinkURL), nil
+}
+
+// setupStorage opens the storage backend selected by the storage.driver config key.
+func setupStorage(cfg *config.Config) (storage.Repository, error) {
+	switch cfg.Storage.Driver {
+	case config.StorageDriverSQLite:
+		s, err := sqlite.New(cfg.StoragePath)
+		if err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	case config.StorageDriverPostgres:
+		s, err := postgres.New(cfg.Storage.DSN)
+		if err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %q", cfg.Storage.Driver)
+	}
+}
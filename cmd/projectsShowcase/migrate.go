@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"projectsShowcase/internal/storage"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = "usage: projectsShowcase migrate up|down|status"
+
+// runMigrate executes the migrate subcommand.
+//
+// up applies all pending migrations, down rolls back the newest applied one,
+// status prints every known migration and whether it is applied.
+func runMigrate(storage storage.Repository, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := storage.Migrator()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("applied %d migration(s), schema version %d\n", applied, migrator.Latest())
+	case "down":
+		migration, err := migrator.Down()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("rolled back %d_%s\n", migration.Version, migration.Name)
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+
+		return w.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const tagsUsage = "usage: projectsShowcase tags rebuild"
+
+type tagsRebuilder interface {
+	RebuildTags(ctx context.Context) (int, error)
+}
+
+// runTags executes the tags subcommand.
+//
+// rebuild parses the keywords of all applications into tags again. Tags are kept up to date
+// when applications are saved, rebuild tags the applications saved before tags were added.
+func runTags(ctx context.Context, rebuilder tagsRebuilder, args []string) error {
+	if len(args) != 1 || args[0] != "rebuild" {
+		return errors.New(tagsUsage)
+	}
+
+	n, err := rebuilder.RebuildTags(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("tagged %d application(s)\n", n)
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("blob key is not valid")
+)
+
+// Store keeps binary objects, such as attachment files, under string keys.
+type Store interface {
+	// Put stores size bytes read from r under key, replacing an existing blob.
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Open returns the content stored under key or ErrNotFound.
+	// The content can be seeked, so it can be served with Range support.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	// ModTime returns when the blob stored under key was last put or ErrNotFound.
+	ModTime(ctx context.Context, key string) (time.Time, error)
+	// Delete removes the blob stored under key, a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// URLSigner is implemented by stores that can give out temporary links to download a blob directly.
+type URLSigner interface {
+	// SignedURL returns a link to the blob valid for ttl, the download is named fileName and has the given type.
+	SignedURL(ctx context.Context, key, fileName, contentType string, ttl time.Duration) (string, error)
+}
+
+// Key returns the content address of the data read from r: the hex SHA-256 of it.
+// Blobs stored under their content address are deduplicated, the same file is kept once.
+func Key(r io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return "", fmt.Errorf("hash content: %w", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,133 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"projectsShowcase/internal/blob"
+	"strings"
+	"time"
+)
+
+// Store keeps blobs as files in a directory, in subdirectories named after the first two characters of the key.
+type Store struct {
+	dir string
+}
+
+// New creates a store in dir, creating the directory if it doesn't exist.
+func New(dir string) (*Store, error) {
+	const op = "blob.local.New"
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Store{dir: dir}, nil
+}
+
+// Put writes the blob to a temporary file first and renames it, so a failed upload never leaves a partial blob.
+func (s *Store) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	const op = "blob.local.Put"
+
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if err == nil && written != size {
+		err = fmt.Errorf("read %d bytes, expected %d", written, size)
+	}
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("%s: write %s: %w", op, key, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%s: write %s: %w", op, key, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Open returns the file of the blob.
+func (s *Store) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	const op = "blob.local.Open"
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %s: %w", op, key, blob.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return file, nil
+}
+
+// ModTime returns the modification time of the file of the blob, Put replaces the file with a new one.
+func (s *Store) ModTime(ctx context.Context, key string) (time.Time, error) {
+	const op = "blob.local.ModTime"
+
+	path, err := s.path(key)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return time.Time{}, fmt.Errorf("%s: %s: %w", op, key, blob.ErrNotFound)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return info.ModTime(), nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	const op = "blob.local.Delete"
+
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// path returns the file of the blob, rejecting keys that could point outside of the store.
+func (s *Store) path(key string) (string, error) {
+	if len(key) < 3 || strings.ContainsAny(key, `/\.`) {
+		return "", fmt.Errorf("%q: %w", key, blob.ErrInvalidKey)
+	}
+
+	return filepath.Join(s.dir, key[:2], key), nil
+}
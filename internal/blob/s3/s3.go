@@ -0,0 +1,133 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"projectsShowcase/internal/blob"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// Options locate the bucket the blobs are kept in.
+type Options struct {
+	// Endpoint is the host and port of the S3 API, e.g. s3.amazonaws.com or localhost:9000 for MinIO.
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// Insecure talks to the endpoint over plain HTTP.
+	Insecure bool
+}
+
+// Store keeps blobs as objects of an S3-compatible bucket, the object names are the keys.
+type Store struct {
+	client *minio.Client
+	bucket string
+}
+
+// New connects to the endpoint and makes sure the bucket exists.
+func New(opts Options) (*Store, error) {
+	const op = "blob.s3.New"
+
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKeyID, opts.SecretAccessKey, ""),
+		Secure: !opts.Insecure,
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	exists, err := client.BucketExists(context.Background(), opts.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("%s: check bucket %s: %w", op, opts.Bucket, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: bucket %s does not exist", op, opts.Bucket)
+	}
+
+	return &Store{client: client, bucket: opts.Bucket}, nil
+}
+
+func (s *Store) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	const op = "blob.s3.Put"
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %s: %w", op, key, err)
+	}
+
+	return nil
+}
+
+// Open returns the object of the blob, it is read with ranged requests from the position it is seeked to.
+func (s *Store) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	const op = "blob.s3.Open"
+
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, key, err)
+	}
+
+	// GetObject is lazy, the object is only requested on the first read.
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("%s: %s: %w", op, key, blob.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %s: %w", op, key, err)
+	}
+
+	return object, nil
+}
+
+// ModTime returns the last modification time of the object, putting it again replaces the object.
+func (s *Store) ModTime(ctx context.Context, key string) (time.Time, error) {
+	const op = "blob.s3.ModTime"
+
+	info, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{})
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return time.Time{}, fmt.Errorf("%s: %s: %w", op, key, blob.ErrNotFound)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %s: %w", op, key, err)
+	}
+
+	return info.LastModified, nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	const op = "blob.s3.Delete"
+
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("%s: %s: %w", op, key, err)
+	}
+
+	return nil
+}
+
+// SignedURL returns a presigned GET link to the object, the response headers of the download are set by the link.
+func (s *Store) SignedURL(ctx context.Context, key, fileName, contentType string, ttl time.Duration) (string, error) {
+	const op = "blob.s3.SignedURL"
+
+	params := url.Values{}
+	params.Set("response-content-type", contentType)
+	params.Set("response-content-disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+
+	u, err := s.client.PresignedGetObject(ctx, s.bucket, key, ttl, params)
+	if err != nil {
+		return "", fmt.Errorf("%s: %s: %w", op, key, err)
+	}
+
+	return u.String(), nil
+}
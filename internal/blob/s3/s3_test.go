@@ -0,0 +1,250 @@
+package s3
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"projectsShowcase/internal/blob"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testBucket = "attachments"
+
+// fakeS3 is an in-memory stand-in for the S3 API with one bucket, it speaks path-style requests
+// and does not check signatures.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != testBucket {
+		s3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	if key == "" {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		s3Error(w, http.StatusNotImplemented, "NotImplemented")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := readPayload(r)
+		if err != nil {
+			s3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+
+		f.objects[key] = data
+		w.Header().Set("ETag", etag(data))
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			s3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+
+		w.Header().Set("ETag", etag(data))
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeContent(w, r, key, time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), bytes.NewReader(data))
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// readPayload returns the request body, decoding the aws-chunked encoding the client uses over plain HTTP.
+func readPayload(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+
+	var data []byte
+	br := bufio.NewReader(r.Body)
+	for {
+		header, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(header), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return data, nil
+		}
+
+		chunk := make([]byte, size+2)
+		if _, err := io.ReadFull(br, chunk); err != nil {
+			return nil, err
+		}
+		data = append(data, chunk[:size]...)
+	}
+}
+
+func etag(data []byte) string {
+	sum := md5.Sum(data)
+
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func s3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
+
+func newTestStore(t *testing.T, bucket string) (*Store, *fakeS3, error) {
+	t.Helper()
+
+	fake := &fakeS3{objects: make(map[string][]byte)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parse server url: %v", err)
+	}
+
+	store, err := New(Options{
+		Endpoint:        u.Host,
+		Region:          "us-east-1",
+		Bucket:          bucket,
+		AccessKeyID:     "test",
+		SecretAccessKey: "testtesttest",
+		Insecure:        true,
+	})
+
+	return store, fake, err
+}
+
+func TestNewMissingBucket(t *testing.T) {
+	if _, _, err := newTestStore(t, "nope"); err == nil {
+		t.Fatal("New succeeded for a bucket that does not exist")
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	store, fake, err := newTestStore(t, testBucket)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	content := []byte("%PDF-1.4 a small attachment")
+	key, err := blob.Key(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+
+	if err := store.Put(ctx, key, bytes.NewReader(content), int64(len(content))); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if got := fake.objects[key]; !bytes.Equal(got, content) {
+		t.Fatalf("stored object = %q, want %q", got, content)
+	}
+
+	rsc, err := store.Open(ctx, key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	if _, err := rsc.Seek(9, io.SeekStart); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+
+	rest, err := io.ReadAll(rsc)
+	rsc.Close()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	if want := content[9:]; !bytes.Equal(rest, want) {
+		t.Errorf("read after seek = %q, want %q", rest, want)
+	}
+
+	modTime, err := store.ModTime(ctx, key)
+	if err != nil {
+		t.Fatalf("ModTime: %v", err)
+	}
+	if want := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC); !modTime.Equal(want) {
+		t.Errorf("ModTime = %v, want %v", modTime, want)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := store.Open(ctx, key); !errors.Is(err, blob.ErrNotFound) {
+		t.Errorf("Open after Delete error = %v, want blob.ErrNotFound", err)
+	}
+
+	if _, err := store.ModTime(ctx, key); !errors.Is(err, blob.ErrNotFound) {
+		t.Errorf("ModTime after Delete error = %v, want blob.ErrNotFound", err)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing blob: %v", err)
+	}
+}
+
+func TestSignedURL(t *testing.T) {
+	store, _, err := newTestStore(t, testBucket)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	raw, err := store.SignedURL(context.Background(), "abc", "отчёт.pdf", "application/pdf", 10*time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse signed url: %v", err)
+	}
+
+	if u.Path != "/"+testBucket+"/abc" {
+		t.Errorf("path = %q, want %q", u.Path, "/"+testBucket+"/abc")
+	}
+
+	q := u.Query()
+	if got := q.Get("response-content-type"); got != "application/pdf" {
+		t.Errorf("response-content-type = %q", got)
+	}
+	if got := q.Get("response-content-disposition"); !strings.HasPrefix(got, "attachment; filename*=") {
+		t.Errorf("response-content-disposition = %q", got)
+	}
+	if got := q.Get("X-Amz-Expires"); got != "600" {
+		t.Errorf("X-Amz-Expires = %q, want 600", got)
+	}
+}
@@ -0,0 +1,125 @@
+package blobgc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"projectsShowcase/internal/blob"
+	"projectsShowcase/internal/lib/logger/sl"
+	"time"
+)
+
+type Repository interface {
+	MarkBlobsOrphaned(ctx context.Context, blobKeys []string) error
+	GetOrphanedBlobs(ctx context.Context, markedBefore time.Time) ([]string, error)
+	ClaimOrphanedBlob(ctx context.Context, blobKey string, markedBefore time.Time) (bool, error)
+}
+
+// Collector deletes the blobs marked as orphaned by the trash purge and by discarded uploads.
+//
+// Blobs are shared by all attachments with the same content, so a blob is only deleted once it has been
+// marked for longer than the grace period, no attachment has it and it hasn't been put again within
+// the grace period: an upload of the same file may have put it and not linked it to its application yet.
+// The grace period has to be longer than an upload may take.
+type Collector struct {
+	log      *slog.Logger
+	repo     Repository
+	blobs    blob.Store
+	grace    time.Duration
+	interval time.Duration
+}
+
+func New(log *slog.Logger, repo Repository, blobs blob.Store, grace, interval time.Duration) *Collector {
+	return &Collector{
+		log:      log.With(slog.String("component", "blobgc")),
+		repo:     repo,
+		blobs:    blobs,
+		grace:    grace,
+		interval: interval,
+	}
+}
+
+// Run collects the orphaned blobs every interval until ctx is done.
+func (c *Collector) Run(ctx context.Context) {
+	c.log.Info("blob collector started",
+		slog.String("grace", c.grace.String()),
+		slog.String("interval", c.interval.String()),
+	)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.Collect(ctx)
+
+		select {
+		case <-ctx.Done():
+			c.log.Info("blob collector stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect deletes the orphaned blobs that have been unused for the grace period.
+func (c *Collector) Collect(ctx context.Context) {
+	markedBefore := time.Now().Add(-c.grace)
+
+	blobKeys, err := c.repo.GetOrphanedBlobs(ctx, markedBefore)
+	if err != nil {
+		c.log.Error("failed to get orphaned blobs", sl.Err(err))
+		return
+	}
+
+	var deleted int
+
+	for _, key := range blobKeys {
+		claimed, err := c.repo.ClaimOrphanedBlob(ctx, key, markedBefore)
+		if err != nil {
+			c.log.Error("failed to claim orphaned blob", slog.String("blob_key", key), sl.Err(err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		ok, err := c.delete(ctx, key, markedBefore)
+		if err != nil {
+			c.log.Error("failed to delete orphaned blob", slog.String("blob_key", key), sl.Err(err))
+		}
+		if !ok {
+			// The blob is kept for now, it is checked again after another grace period.
+			if err := c.repo.MarkBlobsOrphaned(ctx, []string{key}); err != nil {
+				c.log.Error("failed to mark blob as orphaned again", slog.String("blob_key", key), sl.Err(err))
+			}
+			continue
+		}
+
+		deleted++
+	}
+
+	if deleted > 0 {
+		c.log.Info("orphaned blobs deleted", slog.Int("blobs", deleted))
+	}
+}
+
+// delete deletes the claimed blob unless it has been put since putBefore and reports whether it is gone.
+func (c *Collector) delete(ctx context.Context, key string, putBefore time.Time) (bool, error) {
+	modTime, err := c.blobs.ModTime(ctx, key)
+	if errors.Is(err, blob.ErrNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if modTime.After(putBefore) {
+		return false, nil
+	}
+
+	if err := c.blobs.Delete(ctx, key); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,125 @@
+package blobgc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"projectsShowcase/internal/blob"
+	"testing"
+	"time"
+)
+
+// fakeRepo keeps the orphan marks in memory, referenced keys are never claimed.
+type fakeRepo struct {
+	marked     map[string]time.Time
+	referenced map[string]bool
+}
+
+func (r *fakeRepo) MarkBlobsOrphaned(_ context.Context, blobKeys []string) error {
+	for _, key := range blobKeys {
+		r.marked[key] = time.Now()
+	}
+
+	return nil
+}
+
+func (r *fakeRepo) GetOrphanedBlobs(_ context.Context, markedBefore time.Time) ([]string, error) {
+	var keys []string
+	for key, at := range r.marked {
+		if at.Before(markedBefore) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+func (r *fakeRepo) ClaimOrphanedBlob(_ context.Context, blobKey string, markedBefore time.Time) (bool, error) {
+	at, ok := r.marked[blobKey]
+	if !ok {
+		return false, nil
+	}
+	if r.referenced[blobKey] {
+		delete(r.marked, blobKey)
+		return false, nil
+	}
+	if !at.Before(markedBefore) {
+		return false, nil
+	}
+
+	delete(r.marked, blobKey)
+
+	return true, nil
+}
+
+// fakeStore remembers when each blob was put.
+type fakeStore struct {
+	blob.Store
+	put map[string]time.Time
+}
+
+func (s *fakeStore) ModTime(_ context.Context, key string) (time.Time, error) {
+	at, ok := s.put[key]
+	if !ok {
+		return time.Time{}, blob.ErrNotFound
+	}
+
+	return at, nil
+}
+
+func (s *fakeStore) Delete(_ context.Context, key string) error {
+	delete(s.put, key)
+
+	return nil
+}
+
+func TestCollect(t *testing.T) {
+	const grace = time.Hour
+
+	old := time.Now().Add(-2 * grace)
+
+	repo := &fakeRepo{
+		marked: map[string]time.Time{
+			"unused":     old,
+			"reuploaded": old,
+			"attached":   old,
+			"gone":       old,
+			"fresh":      time.Now(),
+		},
+		referenced: map[string]bool{"attached": true},
+	}
+	store := &fakeStore{put: map[string]time.Time{
+		"unused":     old,
+		"reuploaded": time.Now(),
+		"attached":   old,
+		"fresh":      old,
+	}}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	New(log, repo, store, grace, time.Minute).Collect(context.Background())
+
+	if _, ok := store.put["unused"]; ok {
+		t.Error("unused blob was not deleted")
+	}
+
+	for _, key := range []string{"reuploaded", "attached", "fresh"} {
+		if _, ok := store.put[key]; !ok {
+			t.Errorf("blob %q was deleted", key)
+		}
+	}
+
+	// A blob put within the grace period is checked again after another one.
+	if at, ok := repo.marked["reuploaded"]; !ok || at.Before(time.Now().Add(-grace)) {
+		t.Errorf("reuploaded blob mark = %v, %v, want it marked again", at, ok)
+	}
+
+	if _, ok := repo.marked["fresh"]; !ok {
+		t.Error("mark of a recently orphaned blob was dropped")
+	}
+
+	for _, key := range []string{"unused", "attached", "gone"} {
+		if _, ok := repo.marked[key]; ok {
+			t.Errorf("blob %q is still marked", key)
+		}
+	}
+}
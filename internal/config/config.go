@@ -7,18 +7,166 @@ import (
 	"time"
 )
 
+const (
+	StorageDriverSQLite   = "sqlite"
+	StorageDriverPostgres = "postgres"
+)
+
+const (
+	BlobDriverLocal = "local"
+	BlobDriverS3    = "s3"
+)
+
 type Config struct {
-	Env         string `yaml:"env" env-default:"development"`
-	StoragePath string `yaml:"storage_path" env-required:"true"`
-	HTTPServer  `yaml:"http_server"`
+	Env           string `yaml:"env" env-default:"development"`
+	StoragePath   string `yaml:"storage_path"`
+	Storage       `yaml:"storage"`
+	HTTPServer    `yaml:"http_server"`
+	Notifications Notifications `yaml:"notifications"`
+	SelfService   SelfService   `yaml:"self_service"`
+	Trash         Trash         `yaml:"trash"`
+	Attachments   Attachments   `yaml:"attachments"`
+	Metrics       Metrics       `yaml:"metrics"`
+	Health        Health        `yaml:"health"`
+	Tracing       Tracing       `yaml:"tracing"`
+	RateLimit     RateLimit     `yaml:"rate_limit"`
+	Captcha       Captcha       `yaml:"captcha"`
+}
+
+// Storage configures the storage backend.
+//
+// Boolean options default to false: cleanenv can't tell a false value in the file from a missing one.
+type Storage struct {
+	Driver         string `yaml:"driver" env:"STORAGE_DRIVER" env-default:"sqlite"`
+	DSN            string `yaml:"dsn" env:"STORAGE_DSN"`
+	SkipMigrations bool   `yaml:"skip_migrations" env:"STORAGE_SKIP_MIGRATIONS"`
 }
 
 type HTTPServer struct {
 	Address     string        `yaml:"address" env-default:"0.0.0.0:8080"`
 	Timeout     time.Duration `yaml:"timeout" env-default:"5s"`
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
-	User        string        `yaml:"user" env-required:"true"`
-	Password    string        `yaml:"password" env-required:"true" env:"HTTP_SERVER_PASSWORD"`
+	// User and Password are the credentials of the superadmin created when there are no admins yet.
+	User       string        `yaml:"user"`
+	Password   string        `yaml:"password" env:"HTTP_SERVER_PASSWORD"`
+	SessionTTL time.Duration `yaml:"session_ttl" env-default:"12h"`
+	// InsecureCookies allows sending the session cookie over plain HTTP, for local development.
+	InsecureCookies bool `yaml:"insecure_cookies" env:"HTTP_SERVER_INSECURE_COOKIES"`
+}
+
+// Notifications configures the emails sent to applicants.
+type Notifications struct {
+	Enabled      bool          `yaml:"enabled" env:"NOTIFICATIONS_ENABLED"`
+	Locale       string        `yaml:"locale" env-default:"ru"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"10s"`
+	MaxAttempts  int           `yaml:"max_attempts" env-default:"8"`
+	SMTP         SMTP          `yaml:"smtp"`
+}
+
+type SMTP struct {
+	Host     string        `yaml:"host" env:"SMTP_HOST"`
+	Port     int           `yaml:"port" env:"SMTP_PORT" env-default:"587"`
+	Username string        `yaml:"username" env:"SMTP_USERNAME"`
+	Password string        `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string        `yaml:"from" env:"SMTP_FROM"`
+	Timeout  time.Duration `yaml:"timeout" env-default:"30s"`
+}
+
+// SelfService configures the links applicants view and edit their applications with.
+type SelfService struct {
+	// Secret is the key the links are signed with. If it is empty, a random key is used
+	// and the links stop working when the server restarts.
+	Secret   string        `yaml:"secret" env:"SELF_SERVICE_SECRET"`
+	TokenTTL time.Duration `yaml:"token_ttl" env-default:"720h"`
+	// LinkURL is the address of the self-service page in the notifications, the token is appended to it.
+	LinkURL string `yaml:"link_url"`
+}
+
+// Trash configures the purge of deleted applications.
+type Trash struct {
+	// RetentionDays is how long deleted applications stay in the trash, 0 keeps them forever.
+	RetentionDays int           `yaml:"retention_days" env:"TRASH_RETENTION_DAYS"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+// Attachments configures the files applicants upload with their applications.
+type Attachments struct {
+	// Driver is where the files are kept: "local" for a directory, "s3" for an S3-compatible bucket.
+	Driver string `yaml:"driver" env:"ATTACHMENTS_DRIVER" env-default:"local"`
+	// Dir is the directory of the local driver.
+	Dir           string `yaml:"dir" env:"ATTACHMENTS_DIR" env-default:"./storage/attachments"`
+	S3            S3     `yaml:"s3"`
+	MaxFileSizeMB int64  `yaml:"max_file_size_mb" env-default:"10"`
+	MaxFiles      int    `yaml:"max_files" env-default:"5"`
+	// PresignTTL makes downloads redirect to presigned links valid this long instead of going through the server,
+	// it only applies to the s3 driver. 0 streams the files.
+	PresignTTL time.Duration `yaml:"presign_ttl" env:"ATTACHMENTS_PRESIGN_TTL"`
+	// GCGracePeriod is how long a file no attachment uses is kept before it is deleted. It has to be longer
+	// than an upload may take, an upload of the same file may be about to use it.
+	GCGracePeriod time.Duration `yaml:"gc_grace_period" env-default:"24h"`
+	GCInterval    time.Duration `yaml:"gc_interval" env-default:"1h"`
+}
+
+type S3 struct {
+	// Endpoint is the host and port of the S3 API, without the scheme.
+	Endpoint        string `yaml:"endpoint" env:"S3_ENDPOINT"`
+	Region          string `yaml:"region" env:"S3_REGION"`
+	Bucket          string `yaml:"bucket" env:"S3_BUCKET"`
+	AccessKeyID     string `yaml:"access_key_id" env:"S3_ACCESS_KEY_ID"`
+	SecretAccessKey string `yaml:"secret_access_key" env:"S3_SECRET_ACCESS_KEY"`
+	// Insecure talks to the endpoint over plain HTTP, for a local MinIO.
+	Insecure bool `yaml:"insecure" env:"S3_INSECURE"`
+}
+
+// Metrics configures the Prometheus endpoint at /metrics.
+type Metrics struct {
+	// Token, if set, has to be sent by Prometheus as a bearer token to scrape the metrics.
+	Token string `yaml:"token" env:"METRICS_TOKEN"`
+}
+
+// Health configures the readiness probe at /readyz.
+type Health struct {
+	// MinFreeDiskMB is the free space the SQLite database and the local attachments need for the server to be ready.
+	MinFreeDiskMB uint64        `yaml:"min_free_disk_mb" env-default:"100"`
+	Timeout       time.Duration `yaml:"timeout" env-default:"2s"`
+}
+
+// Tracing configures the export of OpenTelemetry traces.
+type Tracing struct {
+	// Exporter is where the spans go: "otlp" for an OTLP/HTTP collector, "stdout" to print them.
+	// Tracing is off when it is empty.
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	// Endpoint is the host and port of the collector, without the scheme. If it is empty,
+	// OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4318 is used.
+	Endpoint string `yaml:"endpoint" env:"TRACING_ENDPOINT"`
+	// Insecure talks to the collector over plain HTTP.
+	Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+	ServiceName string  `yaml:"service_name" env-default:"projects-showcase"`
+}
+
+// RateLimit configures the token buckets limiting the public submissions.
+//
+// A client gets a burst of submissions and then one more every interval. A burst of 0 turns the limit off.
+type RateLimit struct {
+	IPEvery    time.Duration `yaml:"ip_every" env-default:"1m"`
+	IPBurst    int           `yaml:"ip_burst" env:"RATE_LIMIT_IP_BURST" env-default:"5"`
+	EmailEvery time.Duration `yaml:"email_every" env-default:"1h"`
+	EmailBurst int           `yaml:"email_burst" env:"RATE_LIMIT_EMAIL_BURST" env-default:"3"`
+	// TrustProxy limits by the X-Real-IP or X-Forwarded-For address instead of the connection one.
+	// Only set it behind a reverse proxy that overwrites these headers.
+	TrustProxy bool `yaml:"trust_proxy" env:"RATE_LIMIT_TRUST_PROXY"`
+}
+
+// Captcha configures the CAPTCHA the submissions have to pass.
+type Captcha struct {
+	// Provider is "siteverify" for Cloudflare Turnstile, hCaptcha or reCAPTCHA, "fake" to accept
+	// only the fake token in tests, with env local or test only. There is no CAPTCHA when it is empty.
+	Provider string `yaml:"provider" env:"CAPTCHA_PROVIDER"`
+	// VerifyURL is the siteverify endpoint of the provider.
+	VerifyURL string        `yaml:"verify_url" env:"CAPTCHA_VERIFY_URL"`
+	Secret    string        `yaml:"secret" env:"CAPTCHA_SECRET"`
+	Timeout   time.Duration `yaml:"timeout" env-default:"5s"`
 }
 
 // MustLoad loads the configuration from the specified path and returns a pointer to the Config struct.
@@ -42,5 +190,78 @@ func MustLoad() *Config {
 		log.Fatalf("error reading config file: %s", err)
 	}
 
+	switch cfg.Storage.Driver {
+	case StorageDriverSQLite:
+		if cfg.StoragePath == "" {
+			log.Fatal("storage_path is required for the sqlite storage driver")
+		}
+	case StorageDriverPostgres:
+		if cfg.Storage.DSN == "" {
+			log.Fatal("storage.dsn is required for the postgres storage driver")
+		}
+	default:
+		log.Fatalf("unknown storage driver: %q", cfg.Storage.Driver)
+	}
+
+	if cfg.Notifications.Enabled && (cfg.Notifications.SMTP.Host == "" || cfg.Notifications.SMTP.From == "") {
+		log.Fatal("notifications.smtp.host and notifications.smtp.from are required when notifications are enabled")
+	}
+
+	if cfg.Trash.RetentionDays < 0 {
+		log.Fatal("trash.retention_days must not be negative")
+	}
+
+	// Uploads may take up to 5 minutes, see the save handler.
+	if cfg.Attachments.GCGracePeriod < 10*time.Minute {
+		log.Fatal("attachments.gc_grace_period must be at least 10m")
+	}
+
+	if cfg.Attachments.GCInterval <= 0 {
+		log.Fatal("attachments.gc_interval must be positive")
+	}
+
+	switch cfg.Attachments.Driver {
+	case BlobDriverLocal:
+	case BlobDriverS3:
+		if cfg.Attachments.S3.Endpoint == "" || cfg.Attachments.S3.Bucket == "" {
+			log.Fatal("attachments.s3.endpoint and attachments.s3.bucket are required for the s3 attachments driver")
+		}
+	default:
+		log.Fatalf("unknown attachments driver: %q", cfg.Attachments.Driver)
+	}
+
+	if cfg.Attachments.MaxFileSizeMB <= 0 || cfg.Attachments.MaxFiles <= 0 {
+		log.Fatal("attachments.max_file_size_mb and attachments.max_files must be positive")
+	}
+
+	switch cfg.Tracing.Exporter {
+	case "", "otlp", "stdout":
+	default:
+		log.Fatalf("unknown tracing exporter: %q", cfg.Tracing.Exporter)
+	}
+
+	if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+		log.Fatal("tracing.sample_ratio must be between 0 and 1")
+	}
+
+	if (cfg.RateLimit.IPBurst > 0 && cfg.RateLimit.IPEvery <= 0) || (cfg.RateLimit.EmailBurst > 0 && cfg.RateLimit.EmailEvery <= 0) {
+		log.Fatal("rate_limit.ip_every and rate_limit.email_every must be positive")
+	}
+
+	switch cfg.Captcha.Provider {
+	case "":
+	case "fake":
+		// The fake provider lets every submission with the fake token through.
+		if cfg.Env != "local" && cfg.Env != "test" {
+			log.Fatalf("the fake captcha provider is only allowed in the local and test envs, not in %q", cfg.Env)
+		}
+	case "siteverify":
+		if cfg.Captcha.VerifyURL == "" || cfg.Captcha.Secret == "" {
+			log.Fatal("captcha.verify_url and captcha.secret are required for the siteverify captcha provider")
+		}
+	default:
+		log.Fatalf("unknown captcha provider: %q", cfg.Captcha.Provider)
+	}
+
 	return &cfg
 }
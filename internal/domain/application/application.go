@@ -0,0 +1,107 @@
+package application
+
+import (
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/phone"
+	"strings"
+)
+
+// Request is the application form. Label names the field in the validation messages, as on the form,
+// and a form:"textarea" tag marks the fields with long answers for the form schema.
+type Request struct {
+	ApplicantName           string `json:"applicant_name" label:"ФИО заявителя" validate:"required,max=200"`
+	ApplicantEmail          string `json:"applicant_email" label:"Email" validate:"required,max=254,email"`
+	ApplicantPhone          string `json:"applicant_phone" label:"Телефон" validate:"required,phone"`
+	PositionAndOrganization string `json:"position_and_organization" label:"Должность и организация" validate:"required,max=300"`
+	ProjectDuration         string `json:"project_duration" label:"Продолжительность проекта" validate:"required,enum=project_duration"`
+	ProjectLevel            string `json:"project_level" label:"Уровень проекта" validate:"required,enum=project_level"`
+	ProblemHolder           string `json:"problem_holder" label:"Носитель проблемы" validate:"required,max=500"`
+	ProjectGoal             string `json:"project_goal" form:"textarea" label:"Цель проекта" validate:"required,max=3000"`
+	Barrier                 string `json:"barrier" form:"textarea" label:"Барьер" validate:"required,max=3000"`
+	ExistingSolutions       string `json:"existing_solutions" form:"textarea" label:"Существующие решения" validate:"required,max=3000"`
+	Keywords                string `json:"keywords" label:"Ключевые слова" validate:"max=500"`
+	InterestedParties       string `json:"interested_parties" form:"textarea" label:"Заинтересованные стороны" validate:"required,max=1000"`
+	Consultants             string `json:"consultants" form:"textarea" label:"Консультанты" validate:"max=1000"`
+	AdditionalMaterials     string `json:"additional_materials" form:"textarea" label:"Дополнительные материалы" validate:"max=3000"`
+	ProjectName             string `json:"project_name" label:"Название проекта" validate:"max=200"`
+	// Answers are the answers to the additional questions, checked against the form by an AnswersChecker.
+	Answers models.Answers `json:"answers"`
+}
+
+// Normalize trims the fields, replaces the labels of the project duration and level with their codes
+// and brings the phone number to the E.164 format. Values that can't be parsed are left as they are
+// for the validation to reject.
+func (req Request) Normalize() Request {
+	req.ApplicantName = strings.TrimSpace(req.ApplicantName)
+	req.ApplicantEmail = strings.TrimSpace(req.ApplicantEmail)
+	req.ApplicantPhone = strings.TrimSpace(req.ApplicantPhone)
+	req.PositionAndOrganization = strings.TrimSpace(req.PositionAndOrganization)
+	req.ProjectDuration = strings.TrimSpace(req.ProjectDuration)
+	req.ProjectLevel = strings.TrimSpace(req.ProjectLevel)
+	req.ProblemHolder = strings.TrimSpace(req.ProblemHolder)
+	req.ProjectGoal = strings.TrimSpace(req.ProjectGoal)
+	req.Barrier = strings.TrimSpace(req.Barrier)
+	req.ExistingSolutions = strings.TrimSpace(req.ExistingSolutions)
+	req.Keywords = strings.TrimSpace(req.Keywords)
+	req.InterestedParties = strings.TrimSpace(req.InterestedParties)
+	req.Consultants = strings.TrimSpace(req.Consultants)
+	req.AdditionalMaterials = strings.TrimSpace(req.AdditionalMaterials)
+	req.ProjectName = strings.TrimSpace(req.ProjectName)
+
+	if duration, ok := models.Parse(models.ProjectDurations, req.ProjectDuration); ok {
+		req.ProjectDuration = string(duration)
+	}
+	if level, ok := models.Parse(models.ProjectLevels, req.ProjectLevel); ok {
+		req.ProjectLevel = string(level)
+	}
+
+	if normalized, err := phone.Normalize(req.ApplicantPhone); err == nil {
+		req.ApplicantPhone = normalized
+	}
+
+	return req
+}
+
+// Fields returns the application fields of the request.
+func (req Request) Fields() models.ApplicationFields {
+	return models.ApplicationFields{
+		ApplicantName:           req.ApplicantName,
+		ApplicantEmail:          req.ApplicantEmail,
+		ApplicantPhone:          req.ApplicantPhone,
+		PositionAndOrganization: req.PositionAndOrganization,
+		ProjectDuration:         models.ProjectDuration(req.ProjectDuration),
+		ProjectLevel:            models.ProjectLevel(req.ProjectLevel),
+		ProblemHolder:           req.ProblemHolder,
+		ProjectGoal:             req.ProjectGoal,
+		Barrier:                 req.Barrier,
+		ExistingSolutions:       req.ExistingSolutions,
+		Keywords:                req.Keywords,
+		InterestedParties:       req.InterestedParties,
+		Consultants:             req.Consultants,
+		AdditionalMaterials:     req.AdditionalMaterials,
+		ProjectName:             req.ProjectName,
+		Answers:                 req.Answers,
+	}
+}
+
+// RequestFrom returns the request that would set the given application fields.
+func RequestFrom(fields models.ApplicationFields) Request {
+	return Request{
+		ApplicantName:           fields.ApplicantName,
+		ApplicantEmail:          fields.ApplicantEmail,
+		ApplicantPhone:          fields.ApplicantPhone,
+		PositionAndOrganization: fields.PositionAndOrganization,
+		ProjectDuration:         string(fields.ProjectDuration),
+		ProjectLevel:            string(fields.ProjectLevel),
+		ProblemHolder:           fields.ProblemHolder,
+		ProjectGoal:             fields.ProjectGoal,
+		Barrier:                 fields.Barrier,
+		ExistingSolutions:       fields.ExistingSolutions,
+		Keywords:                fields.Keywords,
+		InterestedParties:       fields.InterestedParties,
+		Consultants:             fields.Consultants,
+		AdditionalMaterials:     fields.AdditionalMaterials,
+		ProjectName:             fields.ProjectName,
+		Answers:                 fields.Answers,
+	}
+}
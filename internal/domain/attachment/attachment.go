@@ -0,0 +1,227 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"projectsShowcase/internal/blob"
+	"projectsShowcase/internal/domain/models"
+	"strings"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+var (
+	ErrTooManyFiles = errors.New("too many files")
+	ErrFileTooLarge = errors.New("file is too large")
+	ErrFileType     = errors.New("file type is not allowed, upload PDF, DOCX or an image")
+)
+
+// RejectedError is returned for files that can't be attached. Err is ErrTooManyFiles, ErrFileTooLarge
+// or ErrFileType, Name is the rejected file, empty for ErrTooManyFiles, and Limit is the number of files
+// or the file size in MB that was exceeded.
+type RejectedError struct {
+	Name  string
+	Limit int64
+	Err   error
+}
+
+func (e *RejectedError) Error() string {
+	switch {
+	case errors.Is(e.Err, ErrTooManyFiles):
+		return fmt.Sprintf("at most %d files can be attached: %v", e.Limit, e.Err)
+	case errors.Is(e.Err, ErrFileTooLarge):
+		return fmt.Sprintf("%s is larger than %d MB: %v", e.Name, e.Limit, e.Err)
+	default:
+		return fmt.Sprintf("%s: %v", e.Name, e.Err)
+	}
+}
+
+func (e *RejectedError) Unwrap() error {
+	return e.Err
+}
+
+// AllowedTypes are the media types of the files applicants can attach, detected from the file content.
+var AllowedTypes = []string{
+	"application/pdf",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"image/jpeg",
+	"image/png",
+	"image/gif",
+	"image/webp",
+}
+
+// multipartOverhead is the room left for the form fields and part headers in an upload.
+const multipartOverhead = 1 << 20
+
+type Repository interface {
+	MarkBlobsOrphaned(ctx context.Context, blobKeys []string) error
+}
+
+// Service checks the files uploaded with applications and keeps them in the blob store.
+//
+// Files are stored under the SHA-256 of their content, so a file attached several times is kept once.
+type Service struct {
+	blobs       blob.Store
+	repo        Repository
+	maxFileSize int64
+	maxFiles    int
+	presignTTL  time.Duration
+}
+
+// New returns a service storing at most maxFiles files of at most maxFileSize bytes per application.
+// If presignTTL is set and the blob store can sign links, downloads are handed off to the store
+// with links valid for presignTTL.
+func New(blobs blob.Store, repo Repository, maxFileSize int64, maxFiles int, presignTTL time.Duration) *Service {
+	return &Service{
+		blobs:       blobs,
+		repo:        repo,
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+		presignTTL:  presignTTL,
+	}
+}
+
+// MaxUploadSize returns the largest request body an upload of the maximum number of files can take.
+func (s *Service) MaxUploadSize() int64 {
+	return int64(s.maxFiles)*s.maxFileSize + multipartOverhead
+}
+
+// Store checks the uploaded files and puts them into the blob store.
+//
+// Too many files, a file that is too large or of a type not in AllowedTypes are reported with *RejectedError.
+// Nothing is stored if any file is rejected.
+// The returned attachments are not linked to an application yet; if that fails, they have to be discarded.
+func (s *Service) Store(ctx context.Context, files []*multipart.FileHeader) ([]models.Attachment, error) {
+	const op = "attachment.Service.Store"
+
+	if len(files) > s.maxFiles {
+		return nil, &RejectedError{Limit: int64(s.maxFiles), Err: ErrTooManyFiles}
+	}
+
+	attachments := make([]models.Attachment, 0, len(files))
+
+	for _, file := range files {
+		attachment, err := s.store(ctx, file)
+		if err != nil {
+			s.Discard(ctx, attachments)
+
+			var rejected *RejectedError
+			if errors.As(err, &rejected) {
+				return nil, err
+			}
+
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+func (s *Service) store(ctx context.Context, fileHeader *multipart.FileHeader) (models.Attachment, error) {
+	name := fileName(fileHeader.Filename)
+
+	if fileHeader.Size > s.maxFileSize {
+		return models.Attachment{}, &RejectedError{Name: name, Limit: s.maxFileSize >> 20, Err: ErrFileTooLarge}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return models.Attachment{}, fmt.Errorf("open %s: %w", name, err)
+	}
+	defer file.Close()
+
+	mtype, err := mimetype.DetectReader(file)
+	if err != nil {
+		return models.Attachment{}, fmt.Errorf("detect type of %s: %w", name, err)
+	}
+
+	if !allowed(mtype) {
+		return models.Attachment{}, &RejectedError{Name: name, Err: fmt.Errorf("%s: %w", mtype.String(), ErrFileType)}
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return models.Attachment{}, fmt.Errorf("rewind %s: %w", name, err)
+	}
+
+	key, err := blob.Key(file)
+	if err != nil {
+		return models.Attachment{}, fmt.Errorf("%s: %w", name, err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return models.Attachment{}, fmt.Errorf("rewind %s: %w", name, err)
+	}
+
+	// The same content is always stored under the same key, putting it again is harmless.
+	if err := s.blobs.Put(ctx, key, file, fileHeader.Size); err != nil {
+		return models.Attachment{}, err
+	}
+
+	return models.Attachment{
+		BlobKey:     key,
+		FileName:    name,
+		ContentType: mtype.String(),
+		Size:        fileHeader.Size,
+	}, nil
+}
+
+// Discard gives up attachments that were stored but not linked to an application.
+//
+// Their content is not deleted right away: another upload of the same file may be using it.
+// The blobs are marked as orphaned instead and the blob collector deletes the ones that stay unused.
+// It is best effort: a blob left behind only takes space.
+func (s *Service) Discard(ctx context.Context, attachments []models.Attachment) {
+	if len(attachments) == 0 {
+		return
+	}
+
+	blobKeys := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		blobKeys = append(blobKeys, attachment.BlobKey)
+	}
+
+	_ = s.repo.MarkBlobsOrphaned(ctx, blobKeys)
+}
+
+// Open returns the content of the attachment.
+func (s *Service) Open(ctx context.Context, attachment models.Attachment) (io.ReadSeekCloser, error) {
+	return s.blobs.Open(ctx, attachment.BlobKey)
+}
+
+// DownloadURL returns a temporary link to download the attachment from the blob store directly,
+// or an empty string if downloads go through the server.
+func (s *Service) DownloadURL(ctx context.Context, attachment models.Attachment) (string, error) {
+	signer, ok := s.blobs.(blob.URLSigner)
+	if s.presignTTL <= 0 || !ok {
+		return "", nil
+	}
+
+	return signer.SignedURL(ctx, attachment.BlobKey, attachment.FileName, attachment.ContentType, s.presignTTL)
+}
+
+func allowed(mtype *mimetype.MIME) bool {
+	for _, allowedType := range AllowedTypes {
+		if mtype.Is(allowedType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fileName returns the base name of an uploaded file, browsers may send full paths.
+func fileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	if name == "." || name == "/" {
+		return "attachment"
+	}
+
+	return name
+}
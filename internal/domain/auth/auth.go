@@ -0,0 +1,245 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/storage"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid login or password")
+	ErrSessionExpired     = errors.New("admin session expired")
+	ErrPasswordTooShort   = errors.New("password is too short")
+)
+
+// MinPasswordLength is the minimal length of an admin password.
+const MinPasswordLength = 8
+
+// roleRanks orders the roles, a role is allowed everything the lower ranked roles are.
+var roleRanks = map[string]int{
+	models.RoleViewer:     1,
+	models.RoleReviewer:   2,
+	models.RoleSuperadmin: 3,
+}
+
+// IsKnownRole reports whether role is one of the admin roles.
+func IsKnownRole(role string) bool {
+	_, ok := roleRanks[role]
+
+	return ok
+}
+
+// Allows reports whether an admin with the given role may do what the required role may.
+func Allows(role, required string) bool {
+	rank, ok := roleRanks[role]
+
+	return ok && rank >= roleRanks[required]
+}
+
+type Repository interface {
+	SaveAdminUser(ctx context.Context, login, passwordHash, role string) (int64, error)
+	GetAdminUserByLogin(ctx context.Context, login string) (*models.AdminUser, error)
+	UpdateAdminUserPassword(ctx context.Context, login, passwordHash string) error
+	CountAdminUsers(ctx context.Context) (int, error)
+	SaveAdminSession(ctx context.Context, tokenHash string, adminUserID int64, expiresAt time.Time) error
+	GetAdminSession(ctx context.Context, tokenHash string) (*models.AdminSession, error)
+	DeleteAdminSession(ctx context.Context, tokenHash string) error
+	DeleteExpiredAdminSessions(ctx context.Context, now time.Time) error
+}
+
+// Service signs admins in and out and resolves their sessions.
+type Service struct {
+	repo       Repository
+	sessionTTL time.Duration
+}
+
+func New(repo Repository, sessionTTL time.Duration) *Service {
+	return &Service{repo: repo, sessionTTL: sessionTTL}
+}
+
+// dummyHash is compared against when the login is unknown,
+// so that unknown logins take as long to reject as wrong passwords.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("projects-showcase"), bcrypt.DefaultCost)
+
+// CheckPassword returns the admin with the given login if the password matches.
+//
+// Unknown logins and wrong passwords are both reported as ErrInvalidCredentials.
+func (s *Service) CheckPassword(ctx context.Context, login, password string) (*models.AdminUser, error) {
+	const op = "auth.CheckPassword"
+
+	user, err := s.repo.GetAdminUserByLogin(ctx, login)
+	if errors.Is(err, storage.ErrAdminUserNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
+	}
+
+	return user, nil
+}
+
+// Login checks the credentials and starts a new session.
+//
+// It returns the session token to be sent to the client, only its hash is stored.
+func (s *Service) Login(ctx context.Context, login, password string) (string, *models.AdminSession, error) {
+	const op = "auth.Login"
+
+	user, err := s.CheckPassword(ctx, login, password)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+
+	if err := s.repo.DeleteExpiredAdminSessions(ctx, now); err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	session := &models.AdminSession{
+		AdminUser: *user,
+		ExpiresAt: now.Add(s.sessionTTL).Truncate(time.Second),
+	}
+
+	if err := s.repo.SaveAdminSession(ctx, hashToken(token), user.ID, session.ExpiresAt); err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, session, nil
+}
+
+// Authenticate returns the admin the session token belongs to.
+//
+// It returns storage.ErrSessionNotFound for an unknown token and ErrSessionExpired for an expired one.
+func (s *Service) Authenticate(ctx context.Context, token string) (*models.AdminUser, error) {
+	const op = "auth.Authenticate"
+
+	session, err := s.repo.GetAdminSession(ctx, hashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !time.Now().Before(session.ExpiresAt) {
+		return nil, fmt.Errorf("%s: %w", op, ErrSessionExpired)
+	}
+
+	return &session.AdminUser, nil
+}
+
+// Logout ends the session, unknown tokens are ignored.
+func (s *Service) Logout(ctx context.Context, token string) error {
+	const op = "auth.Logout"
+
+	if err := s.repo.DeleteAdminSession(ctx, hashToken(token)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// CreateAdmin creates an admin with the given role and returns its ID.
+func (s *Service) CreateAdmin(ctx context.Context, login, password, role string) (int64, error) {
+	const op = "auth.CreateAdmin"
+
+	if !IsKnownRole(role) {
+		return 0, fmt.Errorf("%s: %q: %w", op, role, storage.ErrAdminRole)
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := s.repo.SaveAdminUser(ctx, login, hash, role)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// ChangePassword sets a new password for the admin and ends all of their sessions.
+func (s *Service) ChangePassword(ctx context.Context, login, password string) error {
+	const op = "auth.ChangePassword"
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.repo.UpdateAdminUserPassword(ctx, login, hash); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Bootstrap creates a superadmin with the given credentials if there are no admins yet
+// and reports whether it did.
+func (s *Service) Bootstrap(ctx context.Context, login, password string) (bool, error) {
+	const op = "auth.Bootstrap"
+
+	count, err := s.repo.CountAdminUsers(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if count > 0 {
+		return false, nil
+	}
+
+	if _, err := s.CreateAdmin(ctx, login, password, models.RoleSuperadmin); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return true, nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", ErrPasswordTooShort
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// newToken returns a random session token.
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the value sessions are stored by, so that a database leak doesn't leak the tokens.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
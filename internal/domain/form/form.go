@@ -0,0 +1,217 @@
+package form
+
+import (
+	"context"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/validation"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits of the question definitions.
+const (
+	maxLabelLength  = 300
+	maxOptionLength = 200
+	maxOptions      = 50
+	maxMaxLength    = 10000
+)
+
+// namePattern is what question names look like: they are the keys of the answers in the API and in the database.
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// InvalidFieldError is returned for a question definition the form can't have, Reason is shown to the admin.
+type InvalidFieldError struct {
+	Reason string
+}
+
+func (e *InvalidFieldError) Error() string {
+	return "invalid form field: " + e.Reason
+}
+
+func invalid(format string, args ...any) error {
+	return &InvalidFieldError{Reason: fmt.Sprintf(format, args...)}
+}
+
+type Repository interface {
+	GetFormFields(ctx context.Context) ([]models.FormField, error)
+	GetFormField(ctx context.Context, id int64) (*models.FormField, error)
+	SaveFormField(ctx context.Context, field models.FormField) (int64, error)
+	UpdateFormField(ctx context.Context, field models.FormField) error
+}
+
+// Service manages the additional questions of the application form and checks the answers to them.
+//
+// The core fields of the form are fixed, see application.Request, the questions the admins add
+// are answered in the answers object of the application.
+type Service struct {
+	repo Repository
+}
+
+func New(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Fields returns all questions, the inactive ones included, in the order of the form.
+func (s *Service) Fields(ctx context.Context) ([]models.FormField, error) {
+	const op = "form.Fields"
+
+	fields, err := s.repo.GetFormFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return fields, nil
+}
+
+// ActiveFields returns the questions shown on the form, in its order.
+func (s *Service) ActiveFields(ctx context.Context) ([]models.FormField, error) {
+	const op = "form.ActiveFields"
+
+	fields, err := s.repo.GetFormFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return slices.DeleteFunc(fields, func(field models.FormField) bool { return !field.Active }), nil
+}
+
+// CheckAnswers checks the answers against the questions and returns them normalized.
+//
+// The error is validation.AnswerErrors if any answer is not valid, see validation.Answers.
+func (s *Service) CheckAnswers(ctx context.Context, answers models.Answers) (models.Answers, error) {
+	const op = "form.CheckAnswers"
+
+	fields, err := s.repo.GetFormFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return validation.Answers(fields, answers)
+}
+
+// CreateField adds a question to the form and returns it.
+//
+// It returns *InvalidFieldError for a definition the form can't have
+// and storage.ErrFormFieldExists if there already is a question with the name.
+func (s *Service) CreateField(ctx context.Context, field models.FormField) (*models.FormField, error) {
+	const op = "form.CreateField"
+
+	field = normalize(field)
+	if err := check(field); err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.SaveFormField(ctx, field)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	created, err := s.repo.GetFormField(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// UpdateField replaces the definition of the question with the given ID and returns it.
+//
+// The name and the type can't be changed, the answers given already depend on them.
+// It returns *InvalidFieldError for a definition the form can't have
+// and storage.ErrFormFieldNotFound if there is no such question.
+func (s *Service) UpdateField(ctx context.Context, id int64, field models.FormField) (*models.FormField, error) {
+	const op = "form.UpdateField"
+
+	current, err := s.repo.GetFormField(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	field = normalize(field)
+	if field.Name == "" {
+		field.Name = current.Name
+	}
+	if field.Type == "" {
+		field.Type = current.Type
+	}
+
+	if field.Name != current.Name || field.Type != current.Type {
+		return nil, invalid("the name and the type of a question can't be changed")
+	}
+	if err := check(field); err != nil {
+		return nil, err
+	}
+
+	field.ID = id
+
+	if err := s.repo.UpdateFormField(ctx, field); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := s.repo.GetFormField(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+func normalize(field models.FormField) models.FormField {
+	field.Name = strings.TrimSpace(field.Name)
+	field.Label = strings.TrimSpace(field.Label)
+
+	options := make([]string, 0, len(field.Options))
+	for _, option := range field.Options {
+		options = append(options, strings.TrimSpace(option))
+	}
+	field.Options = options
+
+	return field
+}
+
+// check returns *InvalidFieldError if the form can't have the question.
+func check(field models.FormField) error {
+	if !namePattern.MatchString(field.Name) {
+		return invalid("name must be up to 63 lowercase latin letters, digits and underscores and start with a letter")
+	}
+
+	if field.Label == "" {
+		return invalid("label is required")
+	}
+	if utf8.RuneCountInString(field.Label) > maxLabelLength {
+		return invalid("label must be at most %d characters long", maxLabelLength)
+	}
+
+	if !slices.Contains(models.FieldTypes, field.Type) {
+		return invalid("type must be one of %s", strings.Join(models.Codes(models.FieldTypes), ", "))
+	}
+
+	if field.Type.HasOptions() {
+		if len(field.Options) == 0 || len(field.Options) > maxOptions {
+			return invalid("%s questions must have from 1 to %d options", field.Type, maxOptions)
+		}
+
+		for i, option := range field.Options {
+			if option == "" || utf8.RuneCountInString(option) > maxOptionLength {
+				return invalid("options must be from 1 to %d characters long", maxOptionLength)
+			}
+			if slices.Contains(field.Options[:i], option) {
+				return invalid("option %q is repeated", option)
+			}
+		}
+	} else if len(field.Options) > 0 {
+		return invalid("%s questions can't have options", field.Type)
+	}
+
+	if field.MaxLength < 0 || field.MaxLength > maxMaxLength {
+		return invalid("max length must be from 0 to %d", maxMaxLength)
+	}
+	if field.MaxLength > 0 && field.Type != models.FieldText && field.Type != models.FieldTextarea {
+		return invalid("only text questions can have a max length")
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Admin roles, each one is allowed everything the previous one is.
+const (
+	RoleViewer     = "viewer"
+	RoleReviewer   = "reviewer"
+	RoleSuperadmin = "superadmin"
+)
+
+type AdminUser struct {
+	ID           int64
+	Login        string
+	PasswordHash string
+	Role         string
+	CreatedAt    time.Time
+}
+
+// AdminSession is a signed-in admin, identified by the hash of the session token.
+type AdminSession struct {
+	AdminUser AdminUser
+	ExpiresAt time.Time
+}
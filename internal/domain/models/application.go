@@ -3,13 +3,30 @@ package models
 import "time"
 
 type Application struct {
-	ID                      int64
+	ID       int64
+	PublicID string
+	ApplicationFields
+	Status         Status
+	SubmissionDate time.Time
+	// Revision is the number of edits made to the application fields since it was submitted.
+	Revision int
+}
+
+// DeletedApplication is an application in the trash.
+type DeletedApplication struct {
+	Application
+	DeletedAt time.Time
+	DeletedBy string
+}
+
+// ApplicationFields are the fields of an application filled in by the applicant.
+type ApplicationFields struct {
 	ApplicantName           string
 	ApplicantEmail          string
 	ApplicantPhone          string
 	PositionAndOrganization string
-	ProjectDuration         string
-	ProjectLevel            string
+	ProjectDuration         ProjectDuration
+	ProjectLevel            ProjectLevel
 	ProblemHolder           string
 	ProjectGoal             string
 	Barrier                 string
@@ -19,16 +36,63 @@ type Application struct {
 	Consultants             string
 	AdditionalMaterials     string
 	ProjectName             string
-	Status                  string
-	SubmissionDate          time.Time
+	// Answers are the answers to the additional questions of the form.
+	Answers Answers
 }
 
+// ApprovedApplication is the public projection of an approved application.
+//
+// It leaves out the applicant's contact data and identifies the project by its opaque public ID.
 type ApprovedApplication struct {
+	PublicID          string
 	ProblemHolder     string
 	ProjectGoal       string
 	Barrier           string
 	ExistingSolutions string
 	Keywords          string
 	ProjectName       string
-	ProjectLevel      string
+	ProjectLevel      ProjectLevel
+}
+
+// Approved returns the public projection of the application.
+func (a Application) Approved() ApprovedApplication {
+	return ApprovedApplication{
+		PublicID:          a.PublicID,
+		ProblemHolder:     a.ProblemHolder,
+		ProjectGoal:       a.ProjectGoal,
+		Barrier:           a.Barrier,
+		ExistingSolutions: a.ExistingSolutions,
+		Keywords:          a.Keywords,
+		ProjectName:       a.ProjectName,
+		ProjectLevel:      a.ProjectLevel,
+	}
+}
+
+// ApplicantApplication is the projection of an application shown to the applicant.
+type ApplicantApplication struct {
+	PublicID string
+	ApplicationFields
+	Status         Status
+	SubmissionDate time.Time
+}
+
+// ForApplicant returns the projection of the application shown to the applicant.
+func (a Application) ForApplicant() ApplicantApplication {
+	return ApplicantApplication{
+		PublicID:          a.PublicID,
+		ApplicationFields: a.ApplicationFields,
+		Status:            a.Status,
+		SubmissionDate:    a.SubmissionDate,
+	}
+}
+
+// StatusChange is a single entry of the application status history.
+type StatusChange struct {
+	ID            int64
+	ApplicationID int64
+	FromStatus    Status
+	ToStatus      Status
+	ChangedBy     string
+	Reason        string
+	ChangedAt     time.Time
 }
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Attachment is a file uploaded with an application, its content is kept in the blob store under BlobKey.
+type Attachment struct {
+	ID            int64
+	ApplicationID int64
+	BlobKey       string
+	FileName      string
+	ContentType   string
+	Size          int64
+	CreatedAt     time.Time
+}
+
+// AttachmentInfo is the description of an attachment shown in the API, without the location of its content.
+type AttachmentInfo struct {
+	ID          int64
+	FileName    string
+	ContentType string
+	Size        int64
+	CreatedAt   time.Time
+}
+
+// Info returns the description of the attachment shown in the API.
+func (a Attachment) Info() AttachmentInfo {
+	return AttachmentInfo{
+		ID:          a.ID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// AttachmentInfos returns the descriptions of the attachments shown in the API.
+func AttachmentInfos(attachments []Attachment) []AttachmentInfo {
+	infos := make([]AttachmentInfo, 0, len(attachments))
+	for _, attachment := range attachments {
+		infos = append(infos, attachment.Info())
+	}
+
+	return infos
+}
@@ -0,0 +1,137 @@
+package models
+
+import "strings"
+
+// DefaultLocale is the locale of the labels unless another one is asked for.
+const DefaultLocale = "ru"
+
+// Locales are the locales the labels are translated to.
+var Locales = []string{"ru", "en"}
+
+// Status is the review status of an application. Deleted applications keep their status
+// and are moved to the trash instead.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusRevision Status = "revision"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+// ProjectDuration is how long the project takes.
+type ProjectDuration string
+
+const (
+	DurationOneSemester  ProjectDuration = "one_semester"
+	DurationTwoSemesters ProjectDuration = "two_semesters"
+)
+
+// ProjectLevel is the kind of the project.
+type ProjectLevel string
+
+const (
+	LevelDiagnostic         ProjectLevel = "diagnostic"
+	LevelEducational        ProjectLevel = "educational"
+	LevelEducationalApplied ProjectLevel = "educational_applied"
+	LevelApplied            ProjectLevel = "applied"
+)
+
+// The values of the enums in the order they are offered on the form. The database accepts nothing else.
+var (
+	Statuses         = []Status{StatusPending, StatusRevision, StatusApproved, StatusRejected}
+	ProjectDurations = []ProjectDuration{DurationOneSemester, DurationTwoSemesters}
+	ProjectLevels    = []ProjectLevel{LevelDiagnostic, LevelEducational, LevelEducationalApplied, LevelApplied}
+)
+
+var labels = map[string]map[string]string{
+	"ru": {
+		string(StatusPending):  "На рассмотрении",
+		string(StatusRevision): "На доработке",
+		string(StatusApproved): "Допущена",
+		string(StatusRejected): "Отклонена",
+
+		string(DurationOneSemester):  "1 семестр",
+		string(DurationTwoSemesters): "2 семестра",
+
+		string(LevelDiagnostic):         "Диагностический проект",
+		string(LevelEducational):        "Учебный проект",
+		string(LevelEducationalApplied): "Учебно-прикладной проект",
+		string(LevelApplied):            "Прикладной проект",
+	},
+	"en": {
+		string(StatusPending):  "Under review",
+		string(StatusRevision): "Needs revision",
+		string(StatusApproved): "Approved",
+		string(StatusRejected): "Rejected",
+
+		string(DurationOneSemester):  "1 semester",
+		string(DurationTwoSemesters): "2 semesters",
+
+		string(LevelDiagnostic):         "Diagnostic project",
+		string(LevelEducational):        "Educational project",
+		string(LevelEducationalApplied): "Educational and applied project",
+		string(LevelApplied):            "Applied project",
+	},
+}
+
+// label returns the label of the code in the locale, falling back to the default locale and then to the code itself.
+func label(code, locale string) string {
+	if l, ok := labels[locale][code]; ok {
+		return l
+	}
+	if l, ok := labels[DefaultLocale][code]; ok {
+		return l
+	}
+
+	return code
+}
+
+// Label returns the name of the status shown to people in the locale.
+func (s Status) Label(locale string) string {
+	return label(string(s), locale)
+}
+
+func (d ProjectDuration) Label(locale string) string {
+	return label(string(d), locale)
+}
+
+func (l ProjectLevel) Label(locale string) string {
+	return label(string(l), locale)
+}
+
+// Enum is one of the enums above.
+type Enum interface {
+	~string
+	Label(locale string) string
+}
+
+// Codes returns the codes of the values, as they are stored.
+func Codes[E ~string](values []E) []string {
+	codes := make([]string, len(values))
+	for i, value := range values {
+		codes[i] = string(value)
+	}
+
+	return codes
+}
+
+// Parse returns the value of the enum with the given code or label in any locale, case-insensitively.
+// Labels are accepted for the clients and the spreadsheets that still send them.
+func Parse[E Enum](values []E, s string) (E, bool) {
+	s = strings.TrimSpace(s)
+
+	for _, value := range values {
+		if strings.EqualFold(s, string(value)) {
+			return value, true
+		}
+
+		for _, locale := range Locales {
+			if strings.EqualFold(s, value.Label(locale)) {
+				return value, true
+			}
+		}
+	}
+
+	return "", false
+}
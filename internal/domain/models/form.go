@@ -0,0 +1,133 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType is the kind of input an additional question is answered with.
+type FieldType string
+
+const (
+	FieldText        FieldType = "text"
+	FieldTextarea    FieldType = "textarea"
+	FieldNumber      FieldType = "number"
+	FieldSelect      FieldType = "select"
+	FieldMultiselect FieldType = "multiselect"
+	FieldCheckbox    FieldType = "checkbox"
+)
+
+// FieldTypes are the types an additional question may have. The core fields of the form
+// are also described as email and phone fields, see FieldEmail and FieldPhone.
+var FieldTypes = []FieldType{FieldText, FieldTextarea, FieldNumber, FieldSelect, FieldMultiselect, FieldCheckbox}
+
+// Types of the core fields only.
+const (
+	FieldEmail FieldType = "email"
+	FieldPhone FieldType = "phone"
+)
+
+// HasOptions reports whether the answer is picked from the options of the field.
+func (t FieldType) HasOptions() bool {
+	return t == FieldSelect || t == FieldMultiselect
+}
+
+// FormField is an additional question of the application form, managed by the admins.
+//
+// The answers are stored by Name. Questions are deactivated instead of being deleted, so that
+// the answers given to them stay valid: inactive questions are left out of the form
+// and are never required. MaxLength limits text answers, zero means the default limit.
+type FormField struct {
+	ID        int64
+	Name      string
+	Label     string
+	Type      FieldType
+	Required  bool
+	Options   []string
+	MaxLength int
+	Position  int
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Answers are the answers to the additional questions by question name.
+//
+// Text, select and number answers are strings and numbers, checkboxes are booleans
+// and multiselect answers are lists of options. Unanswered questions are left out.
+// Answers are stored as a JSON object.
+type Answers map[string]any
+
+// Value implements driver.Valuer.
+func (a Answers) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, fmt.Errorf("marshal answers: %w", err)
+	}
+
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner.
+func (a *Answers) Scan(src any) error {
+	var b []byte
+
+	switch src := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		b = []byte(src)
+	case []byte:
+		b = src
+	default:
+		return fmt.Errorf("scan answers: unsupported type %T", src)
+	}
+
+	var answers Answers
+	if err := json.Unmarshal(b, &answers); err != nil {
+		return fmt.Errorf("scan answers: %w", err)
+	}
+	if len(answers) == 0 {
+		answers = nil
+	}
+
+	*a = answers
+
+	return nil
+}
+
+// Text returns the answer to the question as people read it, "" if there is no answer.
+func (a Answers) Text(name string) string {
+	switch value := a[name].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		if value {
+			return "да"
+		}
+		return "нет"
+	case []string:
+		return strings.Join(value, ", ")
+	case []any:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ", ")
+	default:
+		return fmt.Sprint(value)
+	}
+}
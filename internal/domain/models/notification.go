@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Notification kinds, each one has its own message template.
+const (
+	NotificationReceived = "application_received"
+	NotificationApproved = "application_approved"
+	NotificationRejected = "application_rejected"
+)
+
+// Notification outbox statuses.
+const (
+	NotificationPending = "pending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+)
+
+// StatusNotifications maps the statuses the applicant is notified about to the notification kinds.
+var StatusNotifications = map[Status]string{
+	StatusApproved: NotificationApproved,
+	StatusRejected: NotificationRejected,
+}
+
+// Notification is a message to the applicant waiting in the outbox.
+type Notification struct {
+	ID            int64
+	ApplicationID int64
+	Kind          string
+	Recipient     string
+	Status        string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Fields applications can be sorted by.
+const (
+	SortByStatus         = "status"
+	SortBySubmissionDate = "submission_date"
+	SortByProjectName    = "project_name"
+	SortByProjectLevel   = "project_level"
+	SortByID             = "id"
+	SortByRelevance      = "relevance"
+)
+
+// ApplicationQuery selects a page of applications.
+//
+// Empty filters and zero times don't restrict the result, a zero Limit returns all matching applications.
+// SubmittedTo is exclusive. Search is a full-text query and is only used by SearchApplications.
+// Tag selects the applications with the tag of that name or synonym.
+type ApplicationQuery struct {
+	Search           string
+	Tag              string
+	Statuses         []Status
+	ProjectLevels    []ProjectLevel
+	ProjectDurations []ProjectDuration
+	SubmittedFrom    time.Time
+	SubmittedTo      time.Time
+	SortBy           string
+	SortDesc         bool
+	Limit            int
+	Offset           int
+}
+
+// IsSortField reports whether applications can be sorted by field.
+func IsSortField(field string) bool {
+	switch field {
+	case SortByStatus, SortBySubmissionDate, SortByProjectName, SortByProjectLevel, SortByID, SortByRelevance:
+		return true
+	}
+
+	return false
+}
+
+// SearchHit is an application found by a full-text search.
+//
+// Snippet is an HTML-escaped fragment of the matched text with the matches wrapped in <mark> tags.
+type SearchHit struct {
+	Application
+	Snippet string
+}
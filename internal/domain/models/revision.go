@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Authors of application edits.
+const (
+	AuthorApplicant = "applicant"
+	AuthorAdmin     = "admin"
+)
+
+// ApplicationRevision is an immutable version of the application fields.
+//
+// Revision 0 is the application as submitted and has empty Before, every edit adds the next one.
+// EditedBy is the email of the applicant or the login of the admin, depending on AuthorType.
+type ApplicationRevision struct {
+	ID            int64
+	ApplicationID int64
+	Revision      int
+	AuthorType    string
+	EditedBy      string
+	Before        ApplicationFields
+	After         ApplicationFields
+	CreatedAt     time.Time
+}
+
+// FieldChange is the difference of a single field between two versions of an application.
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
@@ -0,0 +1,13 @@
+package models
+
+// Tag is a normalized keyword of the applications.
+//
+// The keywords of an application are parsed into tags when it is saved. A term matches a tag by its name
+// or by one of its synonyms, so "AI" can count as "Искусственный интеллект". Count is the number of
+// applications with the tag: approved ones on the public showcase, all of them for the admins.
+type Tag struct {
+	ID       int64
+	Name     string
+	Synonyms []string
+	Count    int
+}
@@ -0,0 +1,77 @@
+package revision
+
+import (
+	"projectsShowcase/internal/domain/models"
+	"reflect"
+	"slices"
+)
+
+// Diff returns the fields that differ between two versions of an application, in declaration order.
+// The answers to the additional questions are compared one by one and named Answers.<question name>.
+func Diff(before, after models.ApplicationFields) []models.FieldChange {
+	changes := []models.FieldChange{}
+
+	b := reflect.ValueOf(before)
+	a := reflect.ValueOf(after)
+
+	for i := 0; i < b.NumField(); i++ {
+		if b.Field(i).Kind() != reflect.String {
+			continue
+		}
+
+		beforeValue := b.Field(i).String()
+		afterValue := a.Field(i).String()
+
+		if beforeValue != afterValue {
+			changes = append(changes, models.FieldChange{
+				Field:  b.Type().Field(i).Name,
+				Before: beforeValue,
+				After:  afterValue,
+			})
+		}
+	}
+
+	return append(changes, answerChanges(before.Answers, after.Answers)...)
+}
+
+// answerChanges returns the answers that differ, by question name.
+func answerChanges(before, after models.Answers) []models.FieldChange {
+	names := make([]string, 0, len(before)+len(after))
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var changes []models.FieldChange
+	for _, name := range names {
+		beforeValue := before.Text(name)
+		afterValue := after.Text(name)
+
+		if beforeValue != afterValue {
+			changes = append(changes, models.FieldChange{
+				Field:  "Answers." + name,
+				Before: beforeValue,
+				After:  afterValue,
+			})
+		}
+	}
+
+	return changes
+}
+
+// ChangedFields returns the names of the fields the revision changed.
+func ChangedFields(revision models.ApplicationRevision) []string {
+	changes := Diff(revision.Before, revision.After)
+
+	fields := make([]string, 0, len(changes))
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+
+	return fields
+}
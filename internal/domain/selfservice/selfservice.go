@@ -0,0 +1,114 @@
+package selfservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/signedtoken"
+	"time"
+)
+
+var ErrNotEditable = errors.New("application can no longer be edited")
+
+// tokenPurpose separates self-service tokens from other tokens signed with the same key.
+const tokenPurpose = "application-self-service"
+
+type Repository interface {
+	GetApplicationByPublicID(ctx context.Context, publicID string) (*models.Application, error)
+	UpdateApplication(ctx context.Context, id int64, revision int, status models.Status, fields models.ApplicationFields, authorType, editedBy string) error
+}
+
+// Service lets applicants view and fix their applications with a link sent to them after submission.
+//
+// The link carries a signed token with the public ID of the application and an expiry time,
+// so nothing has to be stored to issue it.
+type Service struct {
+	repo     Repository
+	signer   *signedtoken.Signer
+	tokenTTL time.Duration
+	linkURL  string
+}
+
+// New creates the service. linkURL is the address of the self-service page the token is appended to,
+// no links are built if it is empty.
+func New(repo Repository, key []byte, tokenTTL time.Duration, linkURL string) *Service {
+	return &Service{
+		repo:     repo,
+		signer:   signedtoken.New(key, tokenPurpose),
+		tokenTTL: tokenTTL,
+		linkURL:  linkURL,
+	}
+}
+
+// IssueToken returns a new token for the application with the given public ID and its expiry time.
+func (s *Service) IssueToken(publicID string) (string, time.Time) {
+	expiresAt := time.Now().Add(s.tokenTTL).Truncate(time.Second)
+
+	return s.signer.Sign(publicID, expiresAt), expiresAt
+}
+
+// SelfServiceLink returns a link to the self-service page with a new token, or "" if no page is configured.
+func (s *Service) SelfServiceLink(publicID string) string {
+	if s.linkURL == "" {
+		return ""
+	}
+
+	token, _ := s.IssueToken(publicID)
+
+	return s.linkURL + token
+}
+
+// Application returns the application the token was issued for.
+//
+// It returns signedtoken.ErrInvalidToken or signedtoken.ErrTokenExpired for a bad token.
+func (s *Service) Application(ctx context.Context, token string) (*models.Application, error) {
+	const op = "selfservice.Application"
+
+	publicID, err := s.signer.Verify(token, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	application, err := s.repo.GetApplicationByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return application, nil
+}
+
+// Update replaces the fields of the application the token was issued for and returns the updated application.
+//
+// Applications can only be edited while they are pending, otherwise ErrNotEditable is returned.
+// The edit is recorded as a revision authored by the applicant.
+func (s *Service) Update(ctx context.Context, token string, fields models.ApplicationFields) (*models.Application, error) {
+	const op = "selfservice.Update"
+
+	application, err := s.Application(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if application.Status != models.StatusPending {
+		return nil, fmt.Errorf("%s: status %q: %w", op, application.Status, ErrNotEditable)
+	}
+
+	err = s.repo.UpdateApplication(
+		ctx,
+		application.ID,
+		application.Revision,
+		application.Status,
+		fields,
+		models.AuthorApplicant,
+		application.ApplicantEmail,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	application.ApplicationFields = fields
+	application.Revision++
+
+	return application, nil
+}
@@ -0,0 +1,161 @@
+package spreadsheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"io"
+	"projectsShowcase/internal/domain/application"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/table"
+	"projectsShowcase/internal/lib/validation"
+	"strings"
+)
+
+var (
+	ErrNoColumns = errors.New("the first row has no application form fields")
+	ErrNoRows    = errors.New("the table has no applications")
+	ErrInvalid   = errors.New("some applications are not valid")
+)
+
+// RowError lists the problems of a row of an imported table.
+type RowError struct {
+	// Row is the 1-based number of the row in the table, the header is row 1.
+	Row    int
+	Errors []resp.FieldError
+}
+
+// Report is the result of an import.
+type Report struct {
+	// Rows is the number of applications in the table.
+	Rows int
+	// Imported is the number of saved applications, 0 for a dry run or if any row is not valid.
+	Imported int
+	Errors   []RowError
+}
+
+type Repository interface {
+	ImportApplications(ctx context.Context, applications []models.ApplicationFields, importedBy string) ([]int64, error)
+}
+
+// Importer saves the applications from a table filled in outside of the site.
+type Importer struct {
+	repo     Repository
+	validate *validator.Validate
+}
+
+func NewImporter(repo Repository) *Importer {
+	return &Importer{repo: repo, validate: validation.New()}
+}
+
+// Import reads the applications from the table and saves them as pending applications in a single transaction.
+//
+// The first row names the columns, see columnFor, other columns are ignored. Each row is validated like
+// an application submitted on the site. Nothing is saved if any row is not valid or dryRun is set;
+// in the former case the report lists the problems of every row and ErrInvalid is returned.
+func (i *Importer) Import(ctx context.Context, r table.Reader, importedBy string, dryRun bool) (Report, error) {
+	const op = "spreadsheet.Importer.Import"
+
+	header, err := r.ReadRow()
+	if errors.Is(err, io.EOF) {
+		return Report{}, fmt.Errorf("%s: %w", op, ErrNoRows)
+	}
+	if err != nil {
+		return Report{}, fmt.Errorf("%s: read header: %w", op, err)
+	}
+
+	fieldColumns := make([]*column, len(header))
+	found := false
+	for n, name := range header {
+		if c, ok := columnFor(name); ok {
+			fieldColumns[n] = &c
+			found = true
+		}
+	}
+	if !found {
+		return Report{}, fmt.Errorf("%s: %w", op, ErrNoColumns)
+	}
+
+	var (
+		report       Report
+		applications []models.ApplicationFields
+	)
+
+	for rowNumber := 2; ; rowNumber++ {
+		values, err := r.ReadRow()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Report{}, fmt.Errorf("%s: read row %d: %w", op, rowNumber, err)
+		}
+
+		if isBlank(values) {
+			continue
+		}
+
+		var fields models.ApplicationFields
+		for n, value := range values {
+			if n < len(fieldColumns) && fieldColumns[n] != nil {
+				fieldColumns[n].set(&fields, strings.TrimSpace(value))
+			}
+		}
+
+		fields = application.RequestFrom(fields).Normalize().Fields()
+
+		report.Rows++
+		applications = append(applications, fields)
+
+		if problems := i.check(fields); len(problems) > 0 {
+			report.Errors = append(report.Errors, RowError{Row: rowNumber, Errors: problems})
+		}
+	}
+
+	if report.Rows == 0 {
+		return report, fmt.Errorf("%s: %w", op, ErrNoRows)
+	}
+
+	if len(report.Errors) > 0 {
+		return report, fmt.Errorf("%s: %d of %d rows: %w", op, len(report.Errors), report.Rows, ErrInvalid)
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	ids, err := i.repo.ImportApplications(ctx, applications, importedBy)
+	if err != nil {
+		return report, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report.Imported = len(ids)
+
+	return report, nil
+}
+
+// check validates the fields with the rules of application.Request.
+func (i *Importer) check(fields models.ApplicationFields) []resp.FieldError {
+	err := i.validate.Struct(application.RequestFrom(fields))
+	if err == nil {
+		return nil
+	}
+
+	var validateErr validator.ValidationErrors
+	if !errors.As(err, &validateErr) {
+		return []resp.FieldError{{Message: err.Error()}}
+	}
+
+	return resp.ValidationError(validateErr).Details
+}
+
+func isBlank(values []string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,104 @@
+package spreadsheet
+
+import (
+	"projectsShowcase/internal/domain/models"
+	"strconv"
+	"strings"
+)
+
+const dateTimeFormat = "2006-01-02 15:04:05"
+
+// column is an application form field in a spreadsheet.
+type column struct {
+	// label is the header of the column, the name of the field on the form.
+	label string
+	// name is the name of the field in the API, also accepted as the header of an imported column.
+	name string
+	// get returns the value of the field as it is exported, set stores an imported value as it is.
+	get func(fields *models.ApplicationFields) string
+	set func(fields *models.ApplicationFields, value string)
+}
+
+// text is a column of a free text field.
+func text(label, name string, field func(f *models.ApplicationFields) *string) column {
+	return column{
+		label: label,
+		name:  name,
+		get:   func(f *models.ApplicationFields) string { return *field(f) },
+		set:   func(f *models.ApplicationFields, value string) { *field(f) = value },
+	}
+}
+
+// enum is a column of an enum field, exported with the labels people read.
+func enum[E models.Enum](label, name string, field func(f *models.ApplicationFields) *E) column {
+	return column{
+		label: label,
+		name:  name,
+		get:   func(f *models.ApplicationFields) string { return (*field(f)).Label(models.DefaultLocale) },
+		set:   func(f *models.ApplicationFields, value string) { *field(f) = E(value) },
+	}
+}
+
+// columns are the application form fields in the order they are exported.
+var columns = []column{
+	text("ФИО заявителя", "applicant_name", func(f *models.ApplicationFields) *string { return &f.ApplicantName }),
+	text("Email", "applicant_email", func(f *models.ApplicationFields) *string { return &f.ApplicantEmail }),
+	text("Телефон", "applicant_phone", func(f *models.ApplicationFields) *string { return &f.ApplicantPhone }),
+	text("Должность и организация", "position_and_organization", func(f *models.ApplicationFields) *string { return &f.PositionAndOrganization }),
+	enum("Продолжительность проекта", "project_duration", func(f *models.ApplicationFields) *models.ProjectDuration { return &f.ProjectDuration }),
+	enum("Уровень проекта", "project_level", func(f *models.ApplicationFields) *models.ProjectLevel { return &f.ProjectLevel }),
+	text("Носитель проблемы", "problem_holder", func(f *models.ApplicationFields) *string { return &f.ProblemHolder }),
+	text("Цель проекта", "project_goal", func(f *models.ApplicationFields) *string { return &f.ProjectGoal }),
+	text("Барьер", "barrier", func(f *models.ApplicationFields) *string { return &f.Barrier }),
+	text("Существующие решения", "existing_solutions", func(f *models.ApplicationFields) *string { return &f.ExistingSolutions }),
+	text("Ключевые слова", "keywords", func(f *models.ApplicationFields) *string { return &f.Keywords }),
+	text("Заинтересованные стороны", "interested_parties", func(f *models.ApplicationFields) *string { return &f.InterestedParties }),
+	text("Консультанты", "consultants", func(f *models.ApplicationFields) *string { return &f.Consultants }),
+	text("Дополнительные материалы", "additional_materials", func(f *models.ApplicationFields) *string { return &f.AdditionalMaterials }),
+	text("Название проекта", "project_name", func(f *models.ApplicationFields) *string { return &f.ProjectName }),
+}
+
+// Header returns the headers of an exported table: the number, status and submission date
+// of the application followed by the form fields and the additional questions.
+func Header(questions []models.FormField) []string {
+	header := []string{"Номер", "Статус", "Дата подачи (UTC)"}
+	for _, c := range columns {
+		header = append(header, c.label)
+	}
+	for _, question := range questions {
+		header = append(header, question.Label)
+	}
+
+	return header
+}
+
+// Row returns the values of the application in the order of Header with the same questions.
+func Row(application models.Application, questions []models.FormField) []string {
+	row := []string{
+		strconv.FormatInt(application.ID, 10),
+		application.Status.Label(models.DefaultLocale),
+		application.SubmissionDate.UTC().Format(dateTimeFormat),
+	}
+	for _, c := range columns {
+		row = append(row, c.get(&application.ApplicationFields))
+	}
+	for _, question := range questions {
+		row = append(row, application.Answers.Text(question.Name))
+	}
+
+	return row
+}
+
+// columnFor returns the form field column with the given header, matched case-insensitively
+// against both the label and the API name.
+func columnFor(header string) (column, bool) {
+	header = strings.TrimSpace(header)
+
+	for _, c := range columns {
+		if strings.EqualFold(header, c.label) || strings.EqualFold(header, c.name) {
+			return c, true
+		}
+	}
+
+	return column{}, false
+}
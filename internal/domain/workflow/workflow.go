@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/storage"
+)
+
+var ErrTransitionNotAllowed = errors.New("status transition is not allowed")
+
+// transitions lists the statuses an application can be moved to from each status.
+var transitions = map[models.Status][]models.Status{
+	models.StatusPending:  {models.StatusApproved, models.StatusRevision, models.StatusRejected},
+	models.StatusRevision: {models.StatusPending, models.StatusRejected},
+	models.StatusApproved: {models.StatusRevision},
+	models.StatusRejected: {models.StatusPending},
+}
+
+// IsKnownStatus reports whether status is one of the application statuses.
+func IsKnownStatus(status models.Status) bool {
+	_, ok := transitions[status]
+
+	return ok
+}
+
+// CanTransition reports whether an application can be moved from one status to another.
+func CanTransition(from, to models.Status) bool {
+	for _, status := range transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+type StatusRepository interface {
+	GetApplicationByID(ctx context.Context, id int64) (*models.Application, error)
+	UpdateApplicationStatus(ctx context.Context, id int64, from, to models.Status, changedBy, reason string) error
+}
+
+// Service enforces the application status workflow.
+type Service struct {
+	repo StatusRepository
+}
+
+func New(repo StatusRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// ChangeStatus moves the application to the given status on behalf of changedBy.
+//
+// It returns storage.ErrProjectStatus for an unknown status and ErrTransitionNotAllowed
+// if the workflow doesn't allow the move from the current status.
+func (s *Service) ChangeStatus(ctx context.Context, id int64, status models.Status, changedBy, reason string) error {
+	const op = "workflow.ChangeStatus"
+
+	if !IsKnownStatus(status) {
+		return fmt.Errorf("%s: %q: %w", op, status, storage.ErrProjectStatus)
+	}
+
+	application, err := s.repo.GetApplicationByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !CanTransition(application.Status, status) {
+		return fmt.Errorf("%s: %q -> %q: %w", op, application.Status, status, ErrTransitionNotAllowed)
+	}
+
+	if err := s.repo.UpdateApplicationStatus(ctx, id, application.Status, status, changedBy, reason); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
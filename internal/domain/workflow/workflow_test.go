@@ -0,0 +1,139 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/storage"
+	"testing"
+)
+
+var statuses = []models.Status{models.StatusPending, models.StatusRevision, models.StatusApproved, models.StatusRejected}
+
+func TestCanTransition(t *testing.T) {
+	allowed := map[[2]models.Status]bool{
+		{models.StatusPending, models.StatusApproved}:  true,
+		{models.StatusPending, models.StatusRevision}:  true,
+		{models.StatusPending, models.StatusRejected}:  true,
+		{models.StatusRevision, models.StatusPending}:  true,
+		{models.StatusRevision, models.StatusRejected}: true,
+		{models.StatusApproved, models.StatusRevision}: true,
+		{models.StatusRejected, models.StatusPending}:  true,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := allowed[[2]models.Status{from, to}]
+			if got := CanTransition(from, to); got != want {
+				t.Errorf("CanTransition(%s, %s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+
+	if CanTransition("archived", models.StatusPending) || CanTransition(models.StatusPending, "archived") {
+		t.Error("CanTransition allows an unknown status")
+	}
+}
+
+// fakeRepository keeps the application statuses by ID and compares the status on update like the storage does.
+type fakeRepository struct {
+	statuses map[int64]models.Status
+	// beforeUpdate runs between reading the application and updating its status.
+	beforeUpdate func()
+	updates      int
+}
+
+func (r *fakeRepository) GetApplicationByID(_ context.Context, id int64) (*models.Application, error) {
+	status, ok := r.statuses[id]
+	if !ok {
+		return nil, storage.ErrApplicationNotFound
+	}
+
+	application := &models.Application{ID: id}
+	application.Status = status
+
+	return application, nil
+}
+
+func (r *fakeRepository) UpdateApplicationStatus(_ context.Context, id int64, from, to models.Status, _, _ string) error {
+	if r.beforeUpdate != nil {
+		r.beforeUpdate()
+	}
+
+	status, ok := r.statuses[id]
+	if !ok {
+		return storage.ErrApplicationNotFound
+	}
+	if status != from {
+		return storage.ErrStatusConflict
+	}
+
+	r.statuses[id] = to
+	r.updates++
+
+	return nil
+}
+
+func TestChangeStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    models.Status
+		to      models.Status
+		wantErr error
+	}{
+		{name: "approve pending", from: models.StatusPending, to: models.StatusApproved},
+		{name: "send pending to revision", from: models.StatusPending, to: models.StatusRevision},
+		{name: "resubmit revision", from: models.StatusRevision, to: models.StatusPending},
+		{name: "reopen rejected", from: models.StatusRejected, to: models.StatusPending},
+		{name: "approve rejected", from: models.StatusRejected, to: models.StatusApproved, wantErr: ErrTransitionNotAllowed},
+		{name: "reject approved", from: models.StatusApproved, to: models.StatusRejected, wantErr: ErrTransitionNotAllowed},
+		{name: "approve approved", from: models.StatusApproved, to: models.StatusApproved, wantErr: ErrTransitionNotAllowed},
+		{name: "unknown status", from: models.StatusPending, to: "archived", wantErr: storage.ErrProjectStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepository{statuses: map[int64]models.Status{1: tt.from}}
+
+			err := New(repo).ChangeStatus(context.Background(), 1, tt.to, "admin", "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ChangeStatus error = %v, want %v", err, tt.wantErr)
+			}
+
+			want := tt.to
+			if tt.wantErr != nil {
+				want = tt.from
+			}
+			if repo.statuses[1] != want {
+				t.Errorf("status = %s, want %s", repo.statuses[1], want)
+			}
+		})
+	}
+}
+
+func TestChangeStatusNotFound(t *testing.T) {
+	repo := &fakeRepository{statuses: map[int64]models.Status{}}
+
+	err := New(repo).ChangeStatus(context.Background(), 1, models.StatusApproved, "admin", "")
+	if !errors.Is(err, storage.ErrApplicationNotFound) {
+		t.Fatalf("ChangeStatus error = %v, want storage.ErrApplicationNotFound", err)
+	}
+}
+
+func TestChangeStatusConflict(t *testing.T) {
+	repo := &fakeRepository{statuses: map[int64]models.Status{1: models.StatusPending}}
+
+	// Another reviewer rejects the application after it was read: approving it must not win.
+	repo.beforeUpdate = func() {
+		repo.statuses[1] = models.StatusRejected
+	}
+
+	err := New(repo).ChangeStatus(context.Background(), 1, models.StatusApproved, "admin", "")
+	if !errors.Is(err, storage.ErrStatusConflict) {
+		t.Fatalf("ChangeStatus error = %v, want storage.ErrStatusConflict", err)
+	}
+
+	if repo.statuses[1] != models.StatusRejected || repo.updates != 0 {
+		t.Errorf("status = %s after %d updates, want the concurrent rejection kept", repo.statuses[1], repo.updates)
+	}
+}
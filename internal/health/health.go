@@ -0,0 +1,99 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"projectsShowcase/internal/lib/disk"
+	"sync"
+	"time"
+)
+
+var ErrLowDiskSpace = errors.New("not enough free disk space")
+
+// Check is a dependency the server needs to serve requests.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result is the outcome of a check, Err is nil if it passed.
+type Result struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// Checker runs the readiness checks.
+type Checker struct {
+	checks  []Check
+	timeout time.Duration
+}
+
+// New returns a checker running the checks with the given timeout each.
+func New(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: timeout}
+}
+
+// Run runs the checks concurrently and reports whether all of them passed.
+// The results are in the order the checks were given.
+func (c *Checker) Run(ctx context.Context) ([]Result, bool) {
+	results := make([]Result, len(c.checks))
+
+	var wg sync.WaitGroup
+
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+
+			// A check that ignores ctx is still cut off at the timeout, its result is dropped.
+			done := make(chan error, 1)
+			go func() { done <- check.Run(ctx) }()
+
+			var err error
+			select {
+			case err = <-done:
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+
+			results[i] = Result{Name: check.Name, Err: err, Duration: time.Since(start)}
+		}()
+	}
+
+	wg.Wait()
+
+	for _, result := range results {
+		if result.Err != nil {
+			return results, false
+		}
+	}
+
+	return results, true
+}
+
+// DiskSpace returns a check that fails when the file system of path has less than minFree bytes available.
+// It passes on systems where free space can't be queried.
+func DiskSpace(path string, minFree uint64) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		free, err := disk.Free(path)
+		if errors.Is(err, disk.ErrUnsupported) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if free < minFree {
+			return fmt.Errorf("%s: %d MB free, %d MB required: %w", path, free>>20, minFree>>20, ErrLowDiskSpace)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+package login
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"io"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/auth"
+	"projectsShowcase/internal/domain/models"
+	authMiddleware "projectsShowcase/internal/http-server/middleware/auth"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/lib/validation"
+	"time"
+)
+
+type Request struct {
+	Login    string `json:"login" label:"Логин" validate:"required"`
+	Password string `json:"password" label:"Пароль" validate:"required"`
+}
+
+type Response struct {
+	resp.Response
+	Login     string    `json:"login,omitempty"`
+	Role      string    `json:"role,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+type SessionStarter interface {
+	Login(ctx context.Context, login, password string) (string, *models.AdminSession, error)
+}
+
+// New returns a handler that signs an admin in and sets the session cookie.
+//
+// secureCookies marks the cookie as HTTPS-only.
+func New(log *slog.Logger, sessionStarter SessionStarter, secureCookies bool) http.HandlerFunc {
+	validate := validation.New()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.login.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "failed to decode request"))
+
+			return
+		}
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		token, session, err := sessionStarter.Login(r.Context(), req.Login, req.Password)
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			log.Info("invalid credentials", slog.String("login", req.Login))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error(resp.CodeUnauthorized, "invalid login or password"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to sign in", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to sign in"))
+
+			return
+		}
+
+		log.Info("admin signed in", slog.String("login", session.AdminUser.Login))
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     authMiddleware.CookieName,
+			Value:    token,
+			Path:     "/admin",
+			Expires:  session.ExpiresAt,
+			Secure:   secureCookies,
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		})
+
+		render.JSON(w, r, Response{
+			Response:  resp.OK(),
+			Login:     session.AdminUser.Login,
+			Role:      session.AdminUser.Role,
+			ExpiresAt: session.ExpiresAt,
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package logout
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	authMiddleware "projectsShowcase/internal/http-server/middleware/auth"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+)
+
+type SessionEnder interface {
+	Logout(ctx context.Context, token string) error
+}
+
+// New returns a handler that ends the session of the admin and clears the session cookie.
+func New(log *slog.Logger, sessionEnder SessionEnder, secureCookies bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.logout.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		if cookie, err := r.Cookie(authMiddleware.CookieName); err == nil {
+			if err := sessionEnder.Logout(r.Context(), cookie.Value); err != nil {
+				log.Error("failed to sign out", sl.Err(err))
+
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to sign out"))
+
+				return
+			}
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     authMiddleware.CookieName,
+			Path:     "/admin",
+			MaxAge:   -1,
+			Secure:   secureCookies,
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		})
+
+		log.Info("admin signed out")
+
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,112 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/domain/spreadsheet"
+	"projectsShowcase/internal/lib/api/filter"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/lib/table"
+	"time"
+)
+
+// writeTimeout replaces the server write timeout for the export, which may take longer than a regular request.
+const writeTimeout = 10 * time.Minute
+
+type ApplicationsExporter interface {
+	ExportApplications(ctx context.Context, q models.ApplicationQuery, fn func(models.Application) error) error
+}
+
+type FormFieldsGetter interface {
+	Fields(ctx context.Context) ([]models.FormField, error)
+}
+
+// New returns a handler exporting applications as a CSV or XLSX table, selected by the format query parameter.
+//
+// It takes the filters and sort order described in filter.Parse except the full-text search.
+// The additional questions of the form, the inactive ones included, follow the form fields.
+// The table is streamed as it is read from the storage, so errors after the first row can only be logged.
+func New(log *slog.Logger, applicationsExporter ApplicationsExporter, formFieldsGetter FormFieldsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.export.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = table.FormatCSV
+		}
+		if format != table.FormatCSV && format != table.FormatXLSX {
+			log.Info("unknown export format", slog.String("format", format))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "format must be csv or xlsx"))
+			return
+		}
+
+		query, err := filter.Parse(r.URL.Query())
+		if err != nil {
+			log.Info("invalid query parameters", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, err.Error()))
+			return
+		}
+		if query.Search != "" {
+			log.Info("search in export", slog.String("q", query.Search))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "export does not support full-text search"))
+			return
+		}
+
+		questions, err := formFieldsGetter.Fields(r.Context())
+		if err != nil {
+			log.Error("failed to get form fields", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to export applications"))
+
+			return
+		}
+
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+			log.Warn("failed to extend write deadline", sl.Err(err))
+		}
+
+		fileName := fmt.Sprintf("applications-%s.%s", time.Now().Format("2006-01-02"), format)
+
+		w.Header().Set("Content-Type", table.ContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+
+		writer, err := table.New(format, w, spreadsheet.Header(questions))
+		if err != nil {
+			log.Error("failed to start export", sl.Err(err))
+			return
+		}
+
+		exported := 0
+
+		err = applicationsExporter.ExportApplications(r.Context(), query, func(application models.Application) error {
+			exported++
+
+			return writer.WriteRow(spreadsheet.Row(application, questions))
+		})
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			log.Error("failed to export applications", sl.Err(err), slog.Int("exported", exported))
+			return
+		}
+
+		log.Info("applications exported", slog.String("format", format), slog.Int("exported", exported))
+	}
+}
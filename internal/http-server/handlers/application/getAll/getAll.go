@@ -1,51 +1,127 @@
 package getAll
 
 import (
+	"context"
+	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
 	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/api/filter"
+	"projectsShowcase/internal/lib/api/paging"
 	resp "projectsShowcase/internal/lib/api/response"
 	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
 )
 
 type Response struct {
 	resp.Response
-	Applications []models.Application `json:"applications,omitempty"`
+	Applications []Application `json:"applications"`
+	Total        int           `json:"total"`
+	Page         int           `json:"page"`
+	PerPage      int           `json:"per_page"`
+}
+
+// Application is an item of the list, Snippet is only set for search results.
+type Application struct {
+	models.Application
+	Snippet string `json:"snippet,omitempty"`
 }
 
 type AllApplicationsGetter interface {
-	GetAllApplications() ([]models.Application, error)
+	GetAllApplications(ctx context.Context, q models.ApplicationQuery) ([]models.Application, int, error)
+	SearchApplications(ctx context.Context, q models.ApplicationQuery) ([]models.SearchHit, int, error)
 }
 
-func New(log *slog.Logger, approvedApplicationsGetter AllApplicationsGetter) http.HandlerFunc {
+// New returns a handler listing applications for admins.
+//
+// It is paged with the page and per_page query parameters and takes the filters described in filter.Parse.
+// Links to the neighbouring pages are returned in the Link header.
+func New(log *slog.Logger, allApplicationsGetter AllApplicationsGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.application.getAll.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
-		applications, err := approvedApplicationsGetter.GetAllApplications()
+		page, err := paging.Parse(r)
+		if err != nil {
+			log.Info("invalid paging parameters", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, err.Error()))
+			return
+		}
+
+		query, err := filter.Parse(r.URL.Query())
+		if err != nil {
+			log.Info("invalid query parameters", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, err.Error()))
+			return
+		}
+
+		query.Limit = page.Size
+		query.Offset = page.Offset()
+
+		applications, total, err := getApplications(r.Context(), allApplicationsGetter, query)
+		if errors.Is(err, storage.ErrEmptySearch) {
+			log.Info("empty search query", slog.String("q", query.Search))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "search query must contain at least one word"))
+			return
+		}
 		if err != nil {
 			log.Error("failed to get all applications", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to get all applications"))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get all applications"))
 
 			return
 		}
 
-		log.Info("get all applications")
+		log.Info("get all applications", slog.Int("total", total), slog.Int("page", page.Number))
 
-		responseOK(w, r, applications)
+		w.Header().Set("Link", paging.Link(r, page, total))
+
+		render.JSON(w, r, Response{
+			Response:     resp.OK(),
+			Applications: applications,
+			Total:        total,
+			Page:         page.Number,
+			PerPage:      page.Size,
+		})
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request, applications []models.Application) {
-	render.JSON(w, r, Response{
-		Response:     resp.OK(),
-		Applications: applications,
-	})
+// getApplications lists the applications matching the query, running a full-text search if it has one.
+func getApplications(ctx context.Context, getter AllApplicationsGetter, query models.ApplicationQuery) ([]Application, int, error) {
+	if query.Search == "" {
+		applications, total, err := getter.GetAllApplications(ctx, query)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		items := make([]Application, 0, len(applications))
+		for _, application := range applications {
+			items = append(items, Application{Application: application})
+		}
+
+		return items, total, nil
+	}
+
+	hits, total, err := getter.SearchApplications(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]Application, 0, len(hits))
+	for _, hit := range hits {
+		items = append(items, Application{Application: hit.Application, Snippet: hit.Snippet})
+	}
+
+	return items, total, nil
 }
@@ -1,6 +1,8 @@
 package getApproved
 
 import (
+	"context"
+	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
@@ -8,58 +10,115 @@ import (
 	"projectsShowcase/internal/domain/models"
 	resp "projectsShowcase/internal/lib/api/response"
 	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strings"
 )
 
 type Response struct {
 	resp.Response
-	Applications []models.ApprovedApplication `json:"applications,omitempty"`
+	Applications []Application `json:"applications"`
+}
+
+// Application is an item of the list, Snippet is only set for search results.
+type Application struct {
+	models.ApprovedApplication
+	Snippet string `json:"snippet,omitempty"`
 }
 
 type ApprovedApplicationsGetter interface {
-	GetApprovedApplications() ([]models.Application, error)
+	GetApprovedApplications(ctx context.Context) ([]models.Application, error)
+	GetAllApplications(ctx context.Context, q models.ApplicationQuery) ([]models.Application, int, error)
+	SearchApplications(ctx context.Context, q models.ApplicationQuery) ([]models.SearchHit, int, error)
 }
 
+// New returns a handler listing approved applications.
+//
+// With the q query parameter it runs a full-text search over the approved applications
+// and returns them ranked by relevance with highlighted snippets. The tag query parameter
+// keeps the applications with the tag of that name or synonym, see GET /tags.
 func New(log *slog.Logger, approvedApplicationsGetter ApprovedApplicationsGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.application.getApproved.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
-		applications, err := approvedApplicationsGetter.GetApprovedApplications()
-		outApplications := []models.ApprovedApplication{}
+		tag := strings.TrimSpace(r.URL.Query().Get("tag"))
 
-		for _, application := range applications {
-			outApplications = append(
-				outApplications,
-				models.ApprovedApplication{
-					ProblemHolder:     application.ProblemHolder,
-					ProjectGoal:       application.ProjectGoal,
-					Barrier:           application.Barrier,
-					ExistingSolutions: application.ExistingSolutions,
-					Keywords:          application.Keywords,
-					ProjectName:       application.ProjectName,
-					ProjectLevel:      application.ProjectLevel,
+		if search := strings.TrimSpace(r.URL.Query().Get("q")); search != "" {
+			hits, _, err := approvedApplicationsGetter.SearchApplications(r.Context(), models.ApplicationQuery{
+				Search:   search,
+				Tag:      tag,
+				Statuses: []models.Status{models.StatusApproved},
+				SortBy:   models.SortByRelevance,
+			})
+			if errors.Is(err, storage.ErrEmptySearch) {
+				log.Info("empty search query", slog.String("q", search))
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, resp.Error(resp.CodeBadRequest, "search query must contain at least one word"))
+				return
+			}
+			if err != nil {
+				log.Error("failed to search approved applications", sl.Err(err))
+
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to search approved applications"))
+
+				return
+			}
+
+			outApplications := []Application{}
+			for _, hit := range hits {
+				outApplications = append(outApplications, Application{
+					ApprovedApplication: hit.Approved(),
+					Snippet:             hit.Snippet,
 				})
+			}
+
+			log.Info("search approved applications", slog.Int("found", len(hits)))
+
+			responseOK(w, r, outApplications)
+
+			return
 		}
 
+		var (
+			applications []models.Application
+			err          error
+		)
+		if tag != "" {
+			applications, _, err = approvedApplicationsGetter.GetAllApplications(r.Context(), models.ApplicationQuery{
+				Tag:      tag,
+				Statuses: []models.Status{models.StatusApproved},
+				SortBy:   models.SortBySubmissionDate,
+			})
+		} else {
+			applications, err = approvedApplicationsGetter.GetApprovedApplications(r.Context())
+		}
 		if err != nil {
 			log.Error("failed to get approved applications", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to get approved applications"))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get approved applications"))
 
 			return
 		}
 
-		log.Info("get approved applications")
+		outApplications := []Application{}
+		for _, application := range applications {
+			outApplications = append(outApplications, Application{ApprovedApplication: application.Approved()})
+		}
+
+		log.Info("get approved applications", slog.String("tag", tag))
 
 		responseOK(w, r, outApplications)
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request, applications []models.ApprovedApplication) {
+func responseOK(w http.ResponseWriter, r *http.Request, applications []Application) {
 	render.JSON(w, r, Response{
 		Response:     resp.OK(),
 		Applications: applications,
@@ -0,0 +1,81 @@
+package getApprovedAttachment
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/http-server/handlers/application/getAttachment"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strconv"
+)
+
+type ApprovedAttachmentGetter interface {
+	GetApprovedApplicationByPublicID(ctx context.Context, publicID string) (*models.Application, error)
+	GetAttachment(ctx context.Context, applicationID, attachmentID int64) (*models.Attachment, error)
+}
+
+// New returns a public handler that downloads an attachment of an approved application.
+//
+// Attachments of applications that are not approved are reported as not found.
+func New(log *slog.Logger, attachmentGetter ApprovedAttachmentGetter, attachmentDownloader getAttachment.AttachmentDownloader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.getApprovedAttachment.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		publicID := chi.URLParam(r, "publicID")
+
+		attachmentID, err := strconv.ParseInt(chi.URLParam(r, "attachmentID"), 10, 64)
+		if err != nil {
+			log.Error("invalid attachment ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid attachment ID format"))
+			return
+		}
+
+		application, err := attachmentGetter.GetApprovedApplicationByPublicID(r.Context(), publicID)
+		if errors.Is(err, storage.ErrApplicationNotFound) {
+			log.Info("approved application not found", slog.String("public_id", publicID))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "attachment not found"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to get approved application", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get attachment"))
+
+			return
+		}
+
+		attachment, err := attachmentGetter.GetAttachment(r.Context(), application.ID, attachmentID)
+		if errors.Is(err, storage.ErrAttachmentNotFound) {
+			log.Info("attachment not found", slog.String("public_id", publicID), slog.Int64("attachment_id", attachmentID))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "attachment not found"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to get attachment", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get attachment"))
+
+			return
+		}
+
+		getAttachment.Serve(w, r, log, attachmentDownloader, *attachment)
+	}
+}
@@ -0,0 +1,79 @@
+package getApprovedByID
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	Application *models.ApprovedApplication `json:"application,omitempty"`
+	Attachments []models.AttachmentInfo     `json:"attachments,omitempty"`
+}
+
+type ApprovedApplicationGetter interface {
+	GetApprovedApplicationByPublicID(ctx context.Context, publicID string) (*models.Application, error)
+	GetApplicationAttachments(ctx context.Context, applicationID int64) ([]models.Attachment, error)
+}
+
+// New returns a public handler that looks up an approved application by its public ID.
+//
+// Applications that are not approved are reported as not found.
+func New(log *slog.Logger, approvedApplicationGetter ApprovedApplicationGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.getApprovedByID.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		publicID := chi.URLParam(r, "publicID")
+
+		application, err := approvedApplicationGetter.GetApprovedApplicationByPublicID(r.Context(), publicID)
+		if errors.Is(err, storage.ErrApplicationNotFound) {
+			log.Info("approved application not found", slog.String("public_id", publicID))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "application not found"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to get approved application", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get application"))
+
+			return
+		}
+
+		attachments, err := approvedApplicationGetter.GetApplicationAttachments(r.Context(), application.ID)
+		if err != nil {
+			log.Error("failed to get attachments", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get application"))
+
+			return
+		}
+
+		log.Info("get approved application", slog.String("public_id", publicID))
+
+		approved := application.Approved()
+
+		render.JSON(w, r, Response{
+			Response:    resp.OK(),
+			Application: &approved,
+			Attachments: models.AttachmentInfos(attachments),
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package getAttachment
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"projectsShowcase/internal/blob"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strconv"
+)
+
+type AttachmentGetter interface {
+	GetAttachment(ctx context.Context, applicationID, attachmentID int64) (*models.Attachment, error)
+}
+
+type AttachmentDownloader interface {
+	DownloadURL(ctx context.Context, attachment models.Attachment) (string, error)
+	Open(ctx context.Context, attachment models.Attachment) (io.ReadSeekCloser, error)
+}
+
+// New returns a handler that downloads an attachment of any application for admins.
+func New(log *slog.Logger, attachmentGetter AttachmentGetter, attachmentDownloader AttachmentDownloader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.getAttachment.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
+			return
+		}
+
+		attachmentID, err := strconv.ParseInt(chi.URLParam(r, "attachmentID"), 10, 64)
+		if err != nil {
+			log.Error("invalid attachment ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid attachment ID format"))
+			return
+		}
+
+		attachment, err := attachmentGetter.GetAttachment(r.Context(), id, attachmentID)
+		if errors.Is(err, storage.ErrAttachmentNotFound) {
+			log.Info("attachment not found", slog.Int64("id", id), slog.Int64("attachment_id", attachmentID))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "attachment not found"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to get attachment", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get attachment"))
+
+			return
+		}
+
+		Serve(w, r, log, attachmentDownloader, *attachment)
+	}
+}
+
+// Serve sends the attachment as a download: a redirect to the blob store if it gives out links,
+// otherwise the content streamed with Range support.
+func Serve(w http.ResponseWriter, r *http.Request, log *slog.Logger, attachmentDownloader AttachmentDownloader, attachment models.Attachment) {
+	link, err := attachmentDownloader.DownloadURL(r.Context(), attachment)
+	if err != nil {
+		log.Error("failed to sign attachment link", sl.Err(err))
+
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get attachment"))
+
+		return
+	}
+	if link != "" {
+		log.Info("redirect to attachment", slog.Int64("attachment_id", attachment.ID))
+
+		http.Redirect(w, r, link, http.StatusFound)
+
+		return
+	}
+
+	content, err := attachmentDownloader.Open(r.Context(), attachment)
+	if errors.Is(err, blob.ErrNotFound) {
+		log.Error("attachment content is missing", slog.Int64("attachment_id", attachment.ID), slog.String("blob_key", attachment.BlobKey))
+
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, resp.Error(resp.CodeNotFound, "attachment not found"))
+
+		return
+	}
+	if err != nil {
+		log.Error("failed to open attachment", sl.Err(err))
+
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get attachment"))
+
+		return
+	}
+	defer content.Close()
+
+	log.Info("download attachment", slog.Int64("attachment_id", attachment.ID))
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// The content stored under a blob key never changes.
+	w.Header().Set("ETag", `"`+attachment.BlobKey+`"`)
+
+	http.ServeContent(w, r, attachment.FileName, attachment.CreatedAt, content)
+}
@@ -1,69 +1,94 @@
 package getByID
 
 import (
+	"context"
+	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
 	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/api/etag"
 	resp "projectsShowcase/internal/lib/api/response"
 	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
 	"strconv"
 )
 
 type Response struct {
 	resp.Response
-	Application *models.Application `json:"application,omitempty"`
+	Application *models.Application     `json:"application,omitempty"`
+	Attachments []models.AttachmentInfo `json:"attachments,omitempty"`
 }
 
 type ApplicationGetter interface {
-	GetApplicationByID(id int64) (*models.Application, error)
+	GetApplicationByID(ctx context.Context, id int64) (*models.Application, error)
+	GetApplicationAttachments(ctx context.Context, applicationID int64) ([]models.Attachment, error)
 }
 
+// New returns a handler showing the full application to admins.
+//
+// The ETag header of the response is the one to send in If-Match when patching the application.
 func New(log *slog.Logger, applicationGetter ApplicationGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.application.getByID.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		idStr := chi.URLParam(r, "id")
 		id, err := strconv.ParseInt(idStr, 10, 64)
 		if err != nil {
 			log.Error("invalid ID format", sl.Err(err))
-			render.JSON(w, r, resp.Error("invalid ID format"))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
 			return
 		}
 
+		application, err := applicationGetter.GetApplicationByID(r.Context(), id)
+		if errors.Is(err, storage.ErrApplicationNotFound) {
+			log.Info("application not found", slog.Int64("id", id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "application not found"))
+			return
+		}
 		if err != nil {
-			log.Error("failed to parse ID", sl.Err(err))
+			log.Error("failed to get application by ID", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to parse ID"))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get application by ID"))
 
 			return
 		}
 
-		application, err := applicationGetter.GetApplicationByID(id)
+		log.Info("get application by ID", slog.Int64("id", id))
+
+		attachments, err := applicationGetter.GetApplicationAttachments(r.Context(), application.ID)
 		if err != nil {
-			log.Error("failed to get application by ID", sl.Err(err))
+			log.Error("failed to get attachments", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to get application by ID"))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get application"))
 
 			return
 		}
 
-		log.Info("get application by ID", slog.Int64("id", id))
+		if tag, err := etag.Of(application); err == nil {
+			w.Header().Set("ETag", tag)
+		}
 
-		responseOK(w, r, application)
+		responseOK(w, r, application, attachments)
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request, application *models.Application) {
+func responseOK(w http.ResponseWriter, r *http.Request, application *models.Application, attachments []models.Attachment) {
 	render.JSON(w, r, Response{
 		Response:    resp.OK(),
 		Application: application,
+		Attachments: models.AttachmentInfos(attachments),
 	})
 }
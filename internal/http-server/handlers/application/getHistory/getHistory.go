@@ -0,0 +1,69 @@
+package getHistory
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strconv"
+)
+
+type Response struct {
+	resp.Response
+	History []models.StatusChange `json:"history"`
+}
+
+type StatusHistoryGetter interface {
+	GetApplicationStatusHistory(ctx context.Context, id int64) ([]models.StatusChange, error)
+}
+
+func New(log *slog.Logger, statusHistoryGetter StatusHistoryGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.getHistory.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			log.Error("invalid ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
+			return
+		}
+
+		history, err := statusHistoryGetter.GetApplicationStatusHistory(r.Context(), id)
+		if errors.Is(err, storage.ErrApplicationNotFound) {
+			log.Info("application not found", slog.Int64("id", id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "application not found"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to get application status history", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get application status history"))
+
+			return
+		}
+
+		log.Info("get application status history", slog.Int64("id", id))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			History:  history,
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package getRevisionDiff
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/domain/revision"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strconv"
+)
+
+type Response struct {
+	resp.Response
+	From    *int                 `json:"from"`
+	To      int                  `json:"to"`
+	Changes []models.FieldChange `json:"changes"`
+}
+
+type ApplicationRevisionGetter interface {
+	GetApplicationRevision(ctx context.Context, id int64, revision int) (*models.ApplicationRevision, error)
+}
+
+// New returns a handler comparing two revisions of an application field by field.
+//
+// Revision n is compared with the one given in the from query parameter,
+// by default with the previous revision. Revision 0 is compared with an empty application by default.
+func New(log *slog.Logger, applicationRevisionGetter ApplicationRevisionGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.getRevisionDiff.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
+			return
+		}
+
+		to, err := strconv.Atoi(chi.URLParam(r, "n"))
+		if err != nil || to < 0 {
+			log.Info("invalid revision", slog.String("n", chi.URLParam(r, "n")))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid revision number"))
+			return
+		}
+
+		var from *int
+		if v := r.URL.Query().Get("from"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				log.Info("invalid from revision", slog.String("from", v))
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid from revision number"))
+				return
+			}
+			from = &n
+		} else if to > 0 {
+			previous := to - 1
+			from = &previous
+		}
+
+		after, err := applicationRevisionGetter.GetApplicationRevision(r.Context(), id, to)
+		if err != nil {
+			responseError(w, r, log, err)
+			return
+		}
+
+		var before models.ApplicationFields
+		if from != nil {
+			revision, err := applicationRevisionGetter.GetApplicationRevision(r.Context(), id, *from)
+			if err != nil {
+				responseError(w, r, log, err)
+				return
+			}
+			before = revision.After
+		}
+
+		log.Info("get application revision diff", slog.Int64("id", id), slog.Int("revision", to))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			From:     from,
+			To:       to,
+			Changes:  revision.Diff(before, after.After),
+		})
+	}
+}
+
+func responseError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	if errors.Is(err, storage.ErrRevisionNotFound) {
+		log.Info("revision not found", sl.Err(err))
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, resp.Error(resp.CodeNotFound, "revision not found"))
+		return
+	}
+
+	log.Error("failed to get application revision", sl.Err(err))
+
+	render.Status(r, http.StatusInternalServerError)
+	render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get application revision"))
+}
@@ -0,0 +1,70 @@
+package getRevisions
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strconv"
+)
+
+type Response struct {
+	resp.Response
+	Revisions []models.ApplicationRevision `json:"revisions"`
+}
+
+type ApplicationRevisionsGetter interface {
+	GetApplicationRevisions(ctx context.Context, id int64) ([]models.ApplicationRevision, error)
+}
+
+// New returns a handler listing the revisions of an application, the submitted version first.
+func New(log *slog.Logger, applicationRevisionsGetter ApplicationRevisionsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.getRevisions.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			log.Error("invalid ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
+			return
+		}
+
+		revisions, err := applicationRevisionsGetter.GetApplicationRevisions(r.Context(), id)
+		if errors.Is(err, storage.ErrApplicationNotFound) {
+			log.Info("application not found", slog.Int64("id", id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "application not found"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to get application revisions", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get application revisions"))
+
+			return
+		}
+
+		log.Info("get application revisions", slog.Int64("id", id))
+
+		render.JSON(w, r, Response{
+			Response:  resp.OK(),
+			Revisions: revisions,
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package getSelf
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/lib/signedtoken"
+	"projectsShowcase/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	Application *models.ApplicantApplication `json:"application,omitempty"`
+}
+
+type SelfApplicationGetter interface {
+	Application(ctx context.Context, token string) (*models.Application, error)
+}
+
+// New returns a handler showing the applicant their application by the self-service token.
+func New(log *slog.Logger, selfApplicationGetter SelfApplicationGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.getSelf.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		application, err := selfApplicationGetter.Application(r.Context(), chi.URLParam(r, "token"))
+		if errors.Is(err, signedtoken.ErrInvalidToken) || errors.Is(err, storage.ErrApplicationNotFound) {
+			log.Info("application not found by token", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "application not found"))
+			return
+		}
+		if errors.Is(err, signedtoken.ErrTokenExpired) {
+			log.Info("token expired")
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, resp.Error(resp.CodeForbidden, "link has expired"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to get application", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get application"))
+
+			return
+		}
+
+		log.Info("get application by token", slog.Int64("id", application.ID))
+
+		own := application.ForApplicant()
+
+		render.JSON(w, r, Response{
+			Response:    resp.OK(),
+			Application: &own,
+		})
+	}
+}
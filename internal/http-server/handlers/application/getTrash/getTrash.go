@@ -0,0 +1,70 @@
+package getTrash
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/api/paging"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+)
+
+type Response struct {
+	resp.Response
+	Applications []models.DeletedApplication `json:"applications"`
+	Total        int                         `json:"total"`
+	Page         int                         `json:"page"`
+	PerPage      int                         `json:"per_page"`
+}
+
+type DeletedApplicationsGetter interface {
+	GetDeletedApplications(ctx context.Context, limit, offset int) ([]models.DeletedApplication, int, error)
+}
+
+// New returns a handler listing the applications in the trash, the most recently deleted first.
+//
+// It is paged with the page and per_page query parameters like the list of applications.
+func New(log *slog.Logger, deletedApplicationsGetter DeletedApplicationsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.getTrash.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		page, err := paging.Parse(r)
+		if err != nil {
+			log.Info("invalid paging parameters", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, err.Error()))
+			return
+		}
+
+		applications, total, err := deletedApplicationsGetter.GetDeletedApplications(r.Context(), page.Size, page.Offset())
+		if err != nil {
+			log.Error("failed to get deleted applications", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get deleted applications"))
+
+			return
+		}
+
+		log.Info("get deleted applications", slog.Int("total", total), slog.Int("page", page.Number))
+
+		w.Header().Set("Link", paging.Link(r, page, total))
+
+		render.JSON(w, r, Response{
+			Response:     resp.OK(),
+			Applications: applications,
+			Total:        total,
+			Page:         page.Number,
+			PerPage:      page.Size,
+		})
+	}
+}
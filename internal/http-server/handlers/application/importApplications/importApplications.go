@@ -0,0 +1,159 @@
+package importApplications
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/spreadsheet"
+	authMiddleware "projectsShowcase/internal/http-server/middleware/auth"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/lib/table"
+	"strconv"
+)
+
+// maxFileSize limits the size of an imported table.
+const maxFileSize = 10 << 20
+
+type Response struct {
+	resp.Response
+	DryRun   bool       `json:"dry_run"`
+	Rows     int        `json:"rows"`
+	Imported int        `json:"imported"`
+	Errors   []RowError `json:"errors,omitempty"`
+}
+
+type RowError struct {
+	Row    int               `json:"row"`
+	Errors []resp.FieldError `json:"errors"`
+}
+
+type ApplicationsImporter interface {
+	Import(ctx context.Context, r table.Reader, importedBy string, dryRun bool) (spreadsheet.Report, error)
+}
+
+// ImportObserver records the imported applications for the metrics.
+type ImportObserver interface {
+	ObserveImported(n int)
+}
+
+// New returns a handler importing applications from a CSV or XLSX table uploaded as the file field of a multipart form.
+//
+// The format is taken from the format query parameter or the file extension. With dry_run=true the table
+// is only validated. If any row is not valid nothing is imported and the problems of every row are returned.
+func New(log *slog.Logger, applicationsImporter ApplicationsImporter, importObserver ImportObserver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.importApplications.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		dryRun := false
+		if v := r.URL.Query().Get("dry_run"); v != "" {
+			var err error
+			if dryRun, err = strconv.ParseBool(v); err != nil {
+				log.Info("invalid dry_run", slog.String("dry_run", v))
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, resp.Error(resp.CodeBadRequest, "dry_run must be true or false"))
+				return
+			}
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxFileSize)
+
+		file, fileHeader, err := r.FormFile("file")
+		if err != nil {
+			log.Info("no file uploaded", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "upload the table as the file field of a multipart form, up to 10 MB"))
+			return
+		}
+		defer file.Close()
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = table.FormatOf(fileHeader.Filename)
+		}
+		if format != table.FormatCSV && format != table.FormatXLSX {
+			log.Info("unknown import format", slog.String("file", fileHeader.Filename), slog.String("format", format))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "the file must be a .csv or .xlsx table"))
+			return
+		}
+
+		reader, err := table.NewReader(format, file)
+		if err != nil {
+			log.Info("failed to open table", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "the file is not a valid "+format+" table"))
+			return
+		}
+		defer reader.Close()
+
+		admin := authMiddleware.Admin(r.Context())
+
+		report, err := applicationsImporter.Import(r.Context(), reader, admin.Login, dryRun)
+
+		var parseErr *csv.ParseError
+
+		switch {
+		case errors.Is(err, spreadsheet.ErrInvalid):
+			log.Info("invalid applications in table", slog.Int("rows", report.Rows), slog.Int("invalid", len(report.Errors)))
+
+			response := responseFrom(report, dryRun)
+			response.Response = resp.Error(resp.CodeValidation, "some rows are not valid, nothing was imported")
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, response)
+
+			return
+		case errors.Is(err, spreadsheet.ErrNoColumns), errors.Is(err, spreadsheet.ErrNoRows), errors.As(err, &parseErr):
+			log.Info("unreadable table", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, errors.Unwrap(err).Error()))
+			return
+		case err != nil:
+			log.Error("failed to import applications", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to import applications"))
+
+			return
+		}
+
+		log.Info("applications imported",
+			slog.String("imported_by", admin.Login),
+			slog.Bool("dry_run", dryRun),
+			slog.Int("rows", report.Rows),
+			slog.Int("imported", report.Imported),
+		)
+
+		importObserver.ObserveImported(report.Imported)
+
+		response := responseFrom(report, dryRun)
+		response.Response = resp.OK()
+
+		render.JSON(w, r, response)
+	}
+}
+
+func responseFrom(report spreadsheet.Report, dryRun bool) Response {
+	response := Response{
+		DryRun:   dryRun,
+		Rows:     report.Rows,
+		Imported: report.Imported,
+	}
+
+	for _, rowErr := range report.Errors {
+		response.Errors = append(response.Errors, RowError{Row: rowErr.Row, Errors: rowErr.Errors})
+	}
+
+	return response
+}
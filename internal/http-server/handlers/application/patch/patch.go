@@ -0,0 +1,252 @@
+package patch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"io"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/application"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/http-server/handlers/application/save"
+	authMiddleware "projectsShowcase/internal/http-server/middleware/auth"
+	"projectsShowcase/internal/lib/api/etag"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/lib/mergepatch"
+	"projectsShowcase/internal/lib/validation"
+	"projectsShowcase/internal/storage"
+	"slices"
+	"strconv"
+)
+
+// MediaType is the content type of JSON Merge Patch requests.
+const MediaType = "application/merge-patch+json"
+
+// maxPatchSize limits the size of the request body.
+const maxPatchSize = 1 << 20
+
+type Response struct {
+	resp.Response
+	Application *models.Application `json:"application,omitempty"`
+}
+
+type ApplicationPatcher interface {
+	GetApplicationByID(ctx context.Context, id int64) (*models.Application, error)
+	UpdateApplication(ctx context.Context, id int64, revision int, status models.Status, fields models.ApplicationFields, authorType, editedBy string) error
+}
+
+// New returns a handler that edits the application fields with a JSON Merge Patch (RFC 7396).
+//
+// The patch members are the fields of application.Request, the result is validated with the same rules.
+// The answers object is merged too, so a patch can change a single answer.
+// The request must carry the ETag of the application in If-Match: it fails with 428 without one
+// and with 412 if the application has changed since. The previous version is kept as a revision.
+func New(log *slog.Logger, applicationPatcher ApplicationPatcher, answersChecker save.AnswersChecker) http.HandlerFunc {
+	validate := validation.New()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.patch.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			log.Error("invalid ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
+			return
+		}
+
+		ifMatch := r.Header.Get("If-Match")
+		if ifMatch == "" {
+			log.Info("If-Match header is missing")
+			render.Status(r, http.StatusPreconditionRequired)
+			render.JSON(w, r, resp.Error(resp.CodePreconditionRequired, "If-Match header is required"))
+			return
+		}
+
+		patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPatchSize))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			log.Info("request body is too large", slog.Int64("limit", tooLarge.Limit))
+			render.Status(r, http.StatusRequestEntityTooLarge)
+			render.JSON(w, r, resp.Error(resp.CodePayloadTooLarge, "merge patch is larger than 1 MB"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to read request body", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "failed to read request"))
+			return
+		}
+
+		application, err := applicationPatcher.GetApplicationByID(r.Context(), id)
+		if errors.Is(err, storage.ErrApplicationNotFound) {
+			log.Info("application not found", slog.Int64("id", id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "application not found"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to get application by ID", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get application by ID"))
+
+			return
+		}
+
+		currentETag, err := etag.Of(application)
+		if err != nil {
+			log.Error("failed to compute ETag", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to update application"))
+
+			return
+		}
+
+		if !etag.Match(ifMatch, currentETag) {
+			log.Info("application has changed", slog.String("if_match", ifMatch), slog.String("etag", currentETag))
+			render.Status(r, http.StatusPreconditionFailed)
+			render.JSON(w, r, resp.Error(resp.CodePreconditionFailed, "application has changed, reload it and try again"))
+			return
+		}
+
+		req, err := apply(application.ApplicationFields, patch)
+		if err != nil {
+			log.Info("invalid merge patch", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, err.Error()))
+			return
+		}
+
+		req = req.Normalize()
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		req.Answers, err = answersChecker.CheckAnswers(r.Context(), req.Answers)
+		var answerErrs validation.AnswerErrors
+		if errors.As(err, &answerErrs) {
+			log.Error("invalid answers", sl.Err(err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.AnswersError(answerErrs))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to check answers", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to update application"))
+
+			return
+		}
+
+		admin := authMiddleware.Admin(r.Context())
+		fields := req.Fields()
+
+		err = applicationPatcher.UpdateApplication(r.Context(), id, application.Revision, application.Status, fields, models.AuthorAdmin, admin.Login)
+		if err != nil {
+			switch {
+			case errors.Is(err, storage.ErrApplicationNotFound):
+				log.Info("application not found", slog.Int64("id", id))
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, resp.Error(resp.CodeNotFound, "application not found"))
+			case errors.Is(err, storage.ErrRevisionConflict):
+				log.Info("application was changed concurrently", sl.Err(err))
+				render.Status(r, http.StatusPreconditionFailed)
+				render.JSON(w, r, resp.Error(resp.CodePreconditionFailed, "application has changed, reload it and try again"))
+			case errors.Is(err, storage.ErrProjectDuration):
+				log.Info("invalid project duration", slog.String("project_duration", req.ProjectDuration))
+				render.Status(r, http.StatusUnprocessableEntity)
+				render.JSON(w, r, resp.Error(resp.CodeValidation, "project duration is not valid"))
+			case errors.Is(err, storage.ErrProjectLevel):
+				log.Info("invalid project level", slog.String("project_level", req.ProjectLevel))
+				render.Status(r, http.StatusUnprocessableEntity)
+				render.JSON(w, r, resp.Error(resp.CodeValidation, "project level is not valid"))
+			default:
+				log.Error("failed to update application", sl.Err(err))
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to update application"))
+			}
+
+			return
+		}
+
+		application.ApplicationFields = fields
+		application.Revision++
+
+		log.Info("application patched", slog.Int64("id", id), slog.Int("revision", application.Revision))
+
+		if newETag, err := etag.Of(application); err == nil {
+			w.Header().Set("ETag", newETag)
+		}
+
+		render.JSON(w, r, Response{
+			Response:    resp.OK(),
+			Application: application,
+		})
+	}
+}
+
+// apply applies the merge patch to the request setting the fields and returns the result.
+//
+// Only the members of application.Request may be patched.
+func apply(fields models.ApplicationFields, patch []byte) (application.Request, error) {
+	doc, err := json.Marshal(application.RequestFrom(fields))
+	if err != nil {
+		return application.Request{}, err
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &members); err != nil {
+		return application.Request{}, err
+	}
+
+	keys, err := mergepatch.Keys(patch)
+	if err != nil {
+		return application.Request{}, fmt.Errorf("request body is not a merge patch: %w", err)
+	}
+
+	slices.Sort(keys)
+	for _, key := range keys {
+		if _, ok := members[key]; !ok {
+			return application.Request{}, fmt.Errorf("field %s cannot be patched", key)
+		}
+	}
+
+	patched, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return application.Request{}, err
+	}
+
+	var result application.Request
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return application.Request{}, fmt.Errorf("patched application is not valid: %w", err)
+	}
+
+	return result, nil
+}
@@ -1,12 +1,14 @@
 package remove
 
 import (
+	"context"
 	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	authMiddleware "projectsShowcase/internal/http-server/middleware/auth"
 	resp "projectsShowcase/internal/lib/api/response"
 	"projectsShowcase/internal/lib/logger/sl"
 	"projectsShowcase/internal/storage"
@@ -14,39 +16,46 @@ import (
 )
 
 type ApplicationRemover interface {
-	DeleteApplication(id int64) error
+	DeleteApplication(ctx context.Context, id int64, deletedBy string) error
 }
 
+// New returns a handler moving an application to the trash, it can be restored from there until it is purged.
 func New(log *slog.Logger, applicationRemover ApplicationRemover) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.application.remove.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		idStr := chi.URLParam(r, "id")
 		id, err := strconv.ParseInt(idStr, 10, 64)
 		if err != nil {
 			log.Error("invalid ID format", sl.Err(err))
-			render.JSON(w, r, resp.Error("invalid ID format"))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
 			return
 		}
 
-		err = applicationRemover.DeleteApplication(id)
+		admin := authMiddleware.Admin(r.Context())
+
+		err = applicationRemover.DeleteApplication(r.Context(), id, admin.Login)
 		if err != nil {
 			if errors.Is(err, storage.ErrApplicationNotFound) {
 				log.Info("application not found", slog.Int64("id", id))
-				render.JSON(w, r, resp.Error("application not found"))
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, resp.Error(resp.CodeNotFound, "application not found"))
 				return
 			}
 			log.Error("failed to delete application", sl.Err(err))
-			render.JSON(w, r, resp.Error("failed to delete application"))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to delete application"))
 			return
 		}
 
-		log.Info("application deleted", slog.Int64("id", id))
+		log.Info("application moved to the trash", slog.Int64("id", id), slog.String("deleted_by", admin.Login))
 		render.JSON(w, r, resp.OK())
 	}
 }
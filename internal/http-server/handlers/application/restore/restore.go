@@ -0,0 +1,58 @@
+package restore
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strconv"
+)
+
+type ApplicationRestorer interface {
+	RestoreApplication(ctx context.Context, id int64) error
+}
+
+// New returns a handler taking an application out of the trash, with the status it was deleted in.
+func New(log *slog.Logger, applicationRestorer ApplicationRestorer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.restore.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			log.Error("invalid ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
+			return
+		}
+
+		err = applicationRestorer.RestoreApplication(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, storage.ErrApplicationNotFound) {
+				log.Info("application not found in the trash", slog.Int64("id", id))
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, resp.Error(resp.CodeNotFound, "application not found in the trash"))
+				return
+			}
+			log.Error("failed to restore application", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to restore application"))
+			return
+		}
+
+		log.Info("application restored", slog.Int64("id", id))
+		render.JSON(w, r, resp.OK())
+	}
+}
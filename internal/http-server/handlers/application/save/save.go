@@ -1,101 +1,351 @@
 package save
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
 	"io"
 	"log/slog"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"projectsShowcase/internal/domain/application"
+	"projectsShowcase/internal/domain/attachment"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/http-server/middleware/ratelimit"
 	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/captcha"
 	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/lib/uuid"
+	"projectsShowcase/internal/lib/validation"
+	"projectsShowcase/internal/storage"
+	"strings"
+	"time"
 )
 
-type Request struct {
-	ApplicantName           string `json:"applicant_name" validate:"required"`
-	ApplicantEmail          string `json:"applicant_email" validate:"required"`
-	ApplicantPhone          string `json:"applicant_phone" validate:"required"`
-	PositionAndOrganization string `json:"position_and_organization" validate:"required"`
-	ProjectDuration         string `json:"project_duration" validate:"required"`
-	ProjectLevel            string `json:"project_level" validate:"required"`
-	ProblemHolder           string `json:"problem_holder" validate:"required"`
-	ProjectGoal             string `json:"project_goal" validate:"required"`
-	Barrier                 string `json:"barrier" validate:"required"`
-	ExistingSolutions       string `json:"existing_solutions" validate:"required"`
-	Keywords                string `json:"keywords"`
-	InterestedParties       string `json:"interested_parties" validate:"required"`
-	Consultants             string `json:"consultants"`
-	AdditionalMaterials     string `json:"additional_materials"`
-	ProjectName             string `json:"project_name"`
+// Form fields of a multipart submission: the application as JSON and the attached files.
+const (
+	FormFieldApplication = "application"
+	FormFieldAttachments = "attachments"
+)
+
+// uploadTimeout is how long a multipart submission may take to upload and be answered,
+// the server read and write timeouts are meant for JSON bodies.
+const uploadTimeout = 5 * time.Minute
+
+// maxMemory is the part of a multipart submission kept in memory, the rest goes to temporary files.
+const maxMemory = 8 << 20
+
+// submission is the body of a new application: the application and the spam protection fields,
+// which are not part of the application.
+type submission struct {
+	application.Request
+	// Website is a honeypot: the form hides it from people, so only bots fill it in.
+	Website      string `json:"website"`
+	CaptchaToken string `json:"captcha_token"`
 }
 
 type Response struct {
 	resp.Response
-	ID int64 `json:"id,omitempty"`
+	ID             string                  `json:"id,omitempty"`
+	Token          string                  `json:"token,omitempty"`
+	TokenExpiresAt time.Time               `json:"token_expires_at,omitempty"`
+	Attachments    []models.AttachmentInfo `json:"attachments,omitempty"`
 }
 
 type ApplicationSaver interface {
-	SaveApplication(applicantName, applicantEmail, applicantPhone, positionAndOrganization, projectDuration, projectLevel, problemHolder, projectGoal, barrier, existingSolutions, keywords, interestedParties, consultants, additionalMaterials, projectName, status string) (int64, error)
+	SaveApplication(ctx context.Context, applicantName, applicantEmail, applicantPhone, positionAndOrganization string, projectDuration models.ProjectDuration, projectLevel models.ProjectLevel, problemHolder, projectGoal, barrier, existingSolutions, keywords, interestedParties, consultants, additionalMaterials, projectName string, answers models.Answers, status models.Status, attachments []models.Attachment) (int64, string, error)
+}
+
+type TokenIssuer interface {
+	IssueToken(publicID string) (string, time.Time)
+}
+
+type AttachmentUploader interface {
+	Store(ctx context.Context, files []*multipart.FileHeader) ([]models.Attachment, error)
+	Discard(ctx context.Context, attachments []models.Attachment)
+	MaxUploadSize() int64
+}
+
+type SubmissionLimiter interface {
+	Allow(key string) (bool, time.Duration)
+}
+
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token string) error
 }
 
-func New(log *slog.Logger, applicationSaver ApplicationSaver) http.HandlerFunc {
+type AnswersChecker interface {
+	CheckAnswers(ctx context.Context, answers models.Answers) (models.Answers, error)
+}
+
+// SubmissionObserver records the saved applications for the metrics.
+type SubmissionObserver interface {
+	ObserveSubmitted()
+}
+
+// New returns a handler that saves a new application.
+//
+// The application is sent either as a JSON body or as multipart/form-data with the JSON in the
+// "application" field and the files in "attachments" parts.
+// The response carries the public ID of the application, a self-service token
+// the applicant can view and edit the application with and the stored attachments.
+// The answers to the additional questions are checked against the form, see form.Service.
+//
+// Before anything is stored the submission has to pass the CAPTCHA and the per-email limit.
+// Submissions with the honeypot filled in are answered as if they were saved and dropped.
+func New(log *slog.Logger, applicationSaver ApplicationSaver, tokenIssuer TokenIssuer, attachmentUploader AttachmentUploader, emailLimiter SubmissionLimiter, captchaVerifier CaptchaVerifier, answersChecker AnswersChecker, submissionObserver SubmissionObserver) http.HandlerFunc {
+	validate := validation.New()
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.application.save.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
-		var req Request
+		var (
+			sub   submission
+			files []*multipart.FileHeader
+		)
+
+		body := r.Body
+
+		if isMultipart(r) {
+			form, err := parseMultipart(w, r, attachmentUploader.MaxUploadSize())
+			if err != nil {
+				log.Error("failed to parse multipart form", sl.Err(err))
+
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, resp.Error(resp.CodeBadRequest, "failed to decode request"))
+
+				return
+			}
+			defer form.RemoveAll()
+
+			values := form.Value[FormFieldApplication]
+			if len(values) == 0 {
+				log.Error("application form field is missing")
 
-		err := render.DecodeJSON(r.Body, &req)
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, resp.Error(resp.CodeBadRequest, "empty request"))
+
+				return
+			}
+
+			body = io.NopCloser(strings.NewReader(values[0]))
+			files = form.File[FormFieldAttachments]
+		}
+
+		err := render.DecodeJSON(body, &sub)
 		if errors.Is(err, io.EOF) {
 			log.Error("request body is empty")
 
-			render.JSON(w, r, resp.Error("empty request"))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "empty request"))
 
 			return
 		}
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to decode request"))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "failed to decode request"))
 
 			return
 		}
 
+		req := sub.Request.Normalize()
+
 		log.Info("request body decoded", slog.Any("req", req))
 
-		if err := validator.New().Struct(req); err != nil {
+		if sub.Website != "" {
+			log.Warn("honeypot filled in, dropping the application", slog.String("website", sub.Website))
+
+			response, err := dropped(tokenIssuer)
+			if err != nil {
+				log.Error("failed to answer dropped application", sl.Err(err))
+
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to add application"))
+
+				return
+			}
+
+			render.JSON(w, r, response)
+
+			return
+		}
+
+		if err := validate.Struct(req); err != nil {
 			validateErr := err.(validator.ValidationErrors)
 
 			log.Error("invalid request", sl.Err(err))
 
+			render.Status(r, http.StatusUnprocessableEntity)
 			render.JSON(w, r, resp.ValidationError(validateErr))
 
 			return
 		}
 
-		id, err := applicationSaver.SaveApplication(req.ApplicantName, req.ApplicantEmail, req.ApplicantPhone, req.PositionAndOrganization, req.ProjectDuration, req.ProjectLevel, req.ProblemHolder, req.ProjectGoal, req.Barrier, req.ExistingSolutions, req.Keywords, req.InterestedParties, req.Consultants, req.AdditionalMaterials, req.ProjectName, "На рассмотрении")
+		req.Answers, err = answersChecker.CheckAnswers(r.Context(), req.Answers)
+		var answerErrs validation.AnswerErrors
+		if errors.As(err, &answerErrs) {
+			log.Error("invalid answers", sl.Err(err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.AnswersError(answerErrs))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to check answers", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to add application"))
+
+			return
+		}
+
+		err = captchaVerifier.Verify(r.Context(), sub.CaptchaToken)
+		if errors.Is(err, captcha.ErrFailed) {
+			log.Info("captcha rejected", sl.Err(err))
+
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, resp.Error(resp.CodeForbidden, "captcha verification failed"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to verify captcha", sl.Err(err))
+
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, resp.Error(resp.CodeUnavailable, "failed to verify captcha, try again later"))
+
+			return
+		}
+
+		if ok, retryAfter := emailLimiter.Allow(strings.ToLower(strings.TrimSpace(req.ApplicantEmail))); !ok {
+			log.Warn("too many applications from the email", slog.String("email", req.ApplicantEmail))
+
+			ratelimit.TooManyRequests(w, r, retryAfter)
+
+			return
+		}
+
+		attachments, err := attachmentUploader.Store(r.Context(), files)
+		var rejected *attachment.RejectedError
+		if errors.As(err, &rejected) {
+			log.Info("attachment rejected", sl.Err(err))
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.AttachmentError(attachmentRule(rejected), rejected.Name, rejected.Limit))
+			return
+		}
+		if err != nil {
+			log.Error("failed to store attachments", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to add application"))
+
+			return
+		}
+
+		id, publicID, err := applicationSaver.SaveApplication(r.Context(), req.ApplicantName, req.ApplicantEmail, req.ApplicantPhone, req.PositionAndOrganization, models.ProjectDuration(req.ProjectDuration), models.ProjectLevel(req.ProjectLevel), req.ProblemHolder, req.ProjectGoal, req.Barrier, req.ExistingSolutions, req.Keywords, req.InterestedParties, req.Consultants, req.AdditionalMaterials, req.ProjectName, req.Answers, models.StatusPending, attachments)
+		if err != nil {
+			attachmentUploader.Discard(r.Context(), attachments)
+		}
+		if errors.Is(err, storage.ErrProjectDuration) {
+			log.Info("invalid project duration", slog.String("project_duration", req.ProjectDuration))
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.Error(resp.CodeValidation, "project duration is not valid"))
+			return
+		}
+		if errors.Is(err, storage.ErrProjectLevel) {
+			log.Info("invalid project level", slog.String("project_level", req.ProjectLevel))
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.Error(resp.CodeValidation, "project level is not valid"))
+			return
+		}
 		if err != nil {
 			log.Error("failed to add application", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to add application"))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to add application"))
 
 			return
 		}
 
-		log.Info("application added", slog.Int64("id", id))
+		log.Info("application added", slog.Int64("id", id), slog.String("public_id", publicID))
 
-		responseOK(w, r, id)
+		submissionObserver.ObserveSubmitted()
+
+		token, expiresAt := tokenIssuer.IssueToken(publicID)
+
+		render.JSON(w, r, Response{
+			Response:       resp.OK(),
+			ID:             publicID,
+			Token:          token,
+			TokenExpiresAt: expiresAt,
+			Attachments:    models.AttachmentInfos(attachments),
+		})
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request, id int64) {
-	render.JSON(w, r, Response{
-		Response: resp.OK(),
-		ID:       id,
-	})
+// dropped returns the answer to a dropped submission. It looks like the answer to a saved one,
+// so bots can't tell, but the public ID is random and the token opens no application.
+func dropped(tokenIssuer TokenIssuer) (Response, error) {
+	publicID, err := uuid.NewV4()
+	if err != nil {
+		return Response{}, err
+	}
+
+	token, expiresAt := tokenIssuer.IssueToken(publicID)
+
+	return Response{
+		Response:       resp.OK(),
+		ID:             publicID,
+		Token:          token,
+		TokenExpiresAt: expiresAt,
+	}, nil
+}
+
+// attachmentRule returns the validation rule the rejected attachment failed.
+func attachmentRule(err *attachment.RejectedError) string {
+	switch {
+	case errors.Is(err, attachment.ErrTooManyFiles):
+		return "max"
+	case errors.Is(err, attachment.ErrFileTooLarge):
+		return "filesize"
+	default:
+		return "filetype"
+	}
+}
+
+func isMultipart(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	return err == nil && mediaType == "multipart/form-data"
+}
+
+// parseMultipart reads a multipart submission of at most maxSize bytes.
+// The caller removes the temporary files of the returned form.
+func parseMultipart(w http.ResponseWriter, r *http.Request, maxSize int64) (*multipart.Form, error) {
+	// The server may not support deadlines, then the timeouts stay as configured.
+	// The write deadline is counted from the start of the request too, so it is extended as well.
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Now().Add(uploadTimeout))
+	_ = rc.SetWriteDeadline(time.Now().Add(uploadTimeout))
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		return nil, fmt.Errorf("parse form: %w", err)
+	}
+
+	return r.MultipartForm, nil
 }
@@ -0,0 +1,217 @@
+package save
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"projectsShowcase/internal/domain/application"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/captcha"
+	"projectsShowcase/internal/lib/ratelimit"
+	"testing"
+	"time"
+)
+
+type fakeSaver struct {
+	saved []string
+}
+
+func (s *fakeSaver) SaveApplication(_ context.Context, _, applicantEmail, _, _ string, _ models.ProjectDuration, _ models.ProjectLevel, _, _, _, _, _, _, _, _, _ string, _ models.Answers, _ models.Status, _ []models.Attachment) (int64, string, error) {
+	s.saved = append(s.saved, applicantEmail)
+
+	return int64(len(s.saved)), "public-id", nil
+}
+
+type fakeTokenIssuer struct{}
+
+func (fakeTokenIssuer) IssueToken(publicID string) (string, time.Time) {
+	return "token-" + publicID, time.Now().Add(time.Hour)
+}
+
+type fakeUploader struct{}
+
+func (fakeUploader) Store(_ context.Context, _ []*multipart.FileHeader) ([]models.Attachment, error) {
+	return nil, nil
+}
+
+func (fakeUploader) Discard(_ context.Context, _ []models.Attachment) {}
+
+func (fakeUploader) MaxUploadSize() int64 {
+	return 1 << 20
+}
+
+type fakeAnswersChecker struct{}
+
+func (fakeAnswersChecker) CheckAnswers(_ context.Context, answers models.Answers) (models.Answers, error) {
+	return answers, nil
+}
+
+type fakeObserver struct {
+	submitted int
+}
+
+func (o *fakeObserver) ObserveSubmitted() {
+	o.submitted++
+}
+
+// validSubmission returns a submission passing the validation, spam protection fields are set by the caller.
+func validSubmission(email string) submission {
+	return submission{
+		Request: application.Request{
+			ApplicantName:           "Иванов Иван Иванович",
+			ApplicantEmail:          email,
+			ApplicantPhone:          "+7 (912) 345-67-89",
+			PositionAndOrganization: "Доцент, УрФУ",
+			ProjectDuration:         string(models.DurationOneSemester),
+			ProjectLevel:            string(models.LevelApplied),
+			ProblemHolder:           "Кафедра",
+			ProjectGoal:             "Цель",
+			Barrier:                 "Барьер",
+			ExistingSolutions:       "Нет",
+			InterestedParties:       "Студенты",
+		},
+	}
+}
+
+type testHandler struct {
+	handler  http.HandlerFunc
+	saver    *fakeSaver
+	observer *fakeObserver
+}
+
+// newTestHandler wires the handler with the fake CAPTCHA and an email limiter allowing one submission an hour.
+func newTestHandler() testHandler {
+	th := testHandler{
+		saver:    &fakeSaver{},
+		observer: &fakeObserver{},
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	th.handler = New(log, th.saver, fakeTokenIssuer{}, fakeUploader{}, ratelimit.New(time.Hour, 1), captcha.Fake{}, fakeAnswersChecker{}, th.observer)
+
+	return th
+}
+
+func (th testHandler) submit(t *testing.T, sub submission) (*httptest.ResponseRecorder, Response) {
+	t.Helper()
+
+	body, err := json.Marshal(sub)
+	if err != nil {
+		t.Fatalf("marshal submission: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/applications", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	th.handler(rec, req)
+
+	var res Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decode response %q: %v", rec.Body.String(), err)
+	}
+
+	return rec, res
+}
+
+func TestSaveCaptcha(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+		wantSaved  int
+	}{
+		{name: "fake token", token: captcha.FakeToken, wantStatus: http.StatusOK, wantSaved: 1},
+		{name: "wrong token", token: "not-the-fake-token", wantStatus: http.StatusForbidden},
+		{name: "no token", token: "", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := newTestHandler()
+
+			sub := validSubmission("ivanov@example.com")
+			sub.CaptchaToken = tt.token
+
+			rec, res := th.submit(t, sub)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+
+			if len(th.saver.saved) != tt.wantSaved || th.observer.submitted != tt.wantSaved {
+				t.Errorf("saved %d and observed %d applications, want %d", len(th.saver.saved), th.observer.submitted, tt.wantSaved)
+			}
+
+			if tt.wantSaved > 0 && (res.ID != "public-id" || res.Token != "token-public-id") {
+				t.Errorf("response id = %q, token = %q", res.ID, res.Token)
+			}
+		})
+	}
+}
+
+func TestSaveHoneypot(t *testing.T) {
+	th := newTestHandler()
+
+	sub := validSubmission("bot@example.com")
+	sub.CaptchaToken = captcha.FakeToken
+	sub.Website = "http://spam.example.com"
+
+	rec, res := th.submit(t, sub)
+
+	if rec.Code != http.StatusOK || res.Status != resp.StatusOK {
+		t.Fatalf("status = %d %q, want the bot to see a success", rec.Code, res.Status)
+	}
+
+	// The answer has the shape of a saved application's, with an ID that belongs to no application.
+	if res.ID == "" || res.ID == "public-id" || res.Token != "token-"+res.ID || res.TokenExpiresAt.IsZero() {
+		t.Errorf("response id = %q, token = %q, expires at %v, want a random ID and its token", res.ID, res.Token, res.TokenExpiresAt)
+	}
+
+	if len(th.saver.saved) != 0 || th.observer.submitted != 0 {
+		t.Errorf("saved %d and observed %d applications, want the honeypot submission dropped", len(th.saver.saved), th.observer.submitted)
+	}
+}
+
+func TestSaveEmailLimit(t *testing.T) {
+	th := newTestHandler()
+
+	first := validSubmission("petrov@example.com")
+	first.CaptchaToken = captcha.FakeToken
+
+	if rec, _ := th.submit(t, first); rec.Code != http.StatusOK {
+		t.Fatalf("first submission status = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// The same address in another case is the same applicant.
+	again := validSubmission("Petrov@Example.com")
+	again.CaptchaToken = captcha.FakeToken
+
+	rec, res := th.submit(t, again)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("second submission status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if res.Code != resp.CodeTooManyRequests {
+		t.Errorf("error code = %q, want %q", res.Code, resp.CodeTooManyRequests)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("Retry-After header is not set")
+	}
+
+	other := validSubmission("sidorov@example.com")
+	other.CaptchaToken = captcha.FakeToken
+
+	if rec, _ := th.submit(t, other); rec.Code != http.StatusOK {
+		t.Errorf("submission from another email status = %d, want it to have its own limit", rec.Code)
+	}
+
+	if want := []string{"petrov@example.com", "sidorov@example.com"}; len(th.saver.saved) != 2 || th.saver.saved[0] != want[0] || th.saver.saved[1] != want[1] {
+		t.Errorf("saved applications from %q, want %q", th.saver.saved, want)
+	}
+}
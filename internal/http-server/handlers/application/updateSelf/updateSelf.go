@@ -0,0 +1,146 @@
+package updateSelf
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"io"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/application"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/domain/selfservice"
+	"projectsShowcase/internal/http-server/handlers/application/save"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/lib/signedtoken"
+	"projectsShowcase/internal/lib/validation"
+	"projectsShowcase/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	Application *models.ApplicantApplication `json:"application,omitempty"`
+}
+
+type SelfApplicationUpdater interface {
+	Update(ctx context.Context, token string, fields models.ApplicationFields) (*models.Application, error)
+}
+
+// New returns a handler that lets the applicant replace the fields of their application by the self-service token.
+//
+// The request body and its validation are the same as for a new application.
+// Only pending applications can be edited.
+func New(log *slog.Logger, selfApplicationUpdater SelfApplicationUpdater, answersChecker save.AnswersChecker) http.HandlerFunc {
+	validate := validation.New()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.application.updateSelf.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		var req application.Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "failed to decode request"))
+
+			return
+		}
+
+		req = req.Normalize()
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		req.Answers, err = answersChecker.CheckAnswers(r.Context(), req.Answers)
+		var answerErrs validation.AnswerErrors
+		if errors.As(err, &answerErrs) {
+			log.Error("invalid answers", sl.Err(err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.AnswersError(answerErrs))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to check answers", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to update application"))
+
+			return
+		}
+
+		application, err := selfApplicationUpdater.Update(r.Context(), chi.URLParam(r, "token"), req.Fields())
+		if err != nil {
+			switch {
+			case errors.Is(err, signedtoken.ErrInvalidToken) || errors.Is(err, storage.ErrApplicationNotFound):
+				log.Info("application not found by token", sl.Err(err))
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, resp.Error(resp.CodeNotFound, "application not found"))
+			case errors.Is(err, signedtoken.ErrTokenExpired):
+				log.Info("token expired")
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, resp.Error(resp.CodeForbidden, "link has expired"))
+			case errors.Is(err, selfservice.ErrNotEditable):
+				log.Info("application is not editable", sl.Err(err))
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, resp.Error(resp.CodeConflict, "application can no longer be edited"))
+			case errors.Is(err, storage.ErrRevisionConflict):
+				log.Info("application was changed concurrently", sl.Err(err))
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, resp.Error(resp.CodeConflict, "application was changed concurrently, try again"))
+			case errors.Is(err, storage.ErrProjectDuration):
+				log.Info("invalid project duration", slog.String("project_duration", req.ProjectDuration))
+				render.Status(r, http.StatusUnprocessableEntity)
+				render.JSON(w, r, resp.Error(resp.CodeValidation, "project duration is not valid"))
+			case errors.Is(err, storage.ErrProjectLevel):
+				log.Info("invalid project level", slog.String("project_level", req.ProjectLevel))
+				render.Status(r, http.StatusUnprocessableEntity)
+				render.JSON(w, r, resp.Error(resp.CodeValidation, "project level is not valid"))
+			default:
+				log.Error("failed to update application", sl.Err(err))
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to update application"))
+			}
+
+			return
+		}
+
+		log.Info("application updated by applicant", slog.Int64("id", application.ID), slog.Int("revision", application.Revision))
+
+		own := application.ForApplicant()
+
+		render.JSON(w, r, Response{
+			Response:    resp.OK(),
+			Application: &own,
+		})
+	}
+}
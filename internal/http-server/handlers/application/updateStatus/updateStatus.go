@@ -1,6 +1,7 @@
 package updateStatus
 
 import (
+	"context"
 	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -9,37 +10,47 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/domain/workflow"
+	authMiddleware "projectsShowcase/internal/http-server/middleware/auth"
 	resp "projectsShowcase/internal/lib/api/response"
 	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/lib/validation"
+	"projectsShowcase/internal/storage"
 	"strconv"
 )
 
 type Request struct {
-	Status string `json:"status" validate:"required"`
+	Status string `json:"status" label:"Статус" validate:"required,enum=status"`
+	Reason string `json:"reason"`
 }
 
 type Response struct {
 	resp.Response
 }
 
-type ApplicationStatusUpdater interface {
-	UpdateApplicationStatus(id int64, status string) error
+type ApplicationStatusChanger interface {
+	ChangeStatus(ctx context.Context, id int64, status models.Status, changedBy, reason string) error
 }
 
-func New(log *slog.Logger, applicationStatusUpdater ApplicationStatusUpdater) http.HandlerFunc {
+func New(log *slog.Logger, applicationStatusChanger ApplicationStatusChanger) http.HandlerFunc {
+	validate := validation.New()
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.application.updateStatus.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		idStr := chi.URLParam(r, "id")
 		id, err := strconv.ParseInt(idStr, 10, 64)
 		if err != nil {
 			log.Error("invalid ID format", sl.Err(err))
-			render.JSON(w, r, resp.Error("invalid ID format"))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
 			return
 		}
 
@@ -49,35 +60,64 @@ func New(log *slog.Logger, applicationStatusUpdater ApplicationStatusUpdater) ht
 		if errors.Is(err, io.EOF) {
 			log.Error("request body is empty")
 
-			render.JSON(w, r, resp.Error("empty request"))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "empty request"))
 
 			return
 		}
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to decode request"))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "failed to decode request"))
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("req", req))
 
-		if err := validator.New().Struct(req); err != nil {
+		// Labels are still accepted for the clients that send them.
+		if status, ok := models.Parse(models.Statuses, req.Status); ok {
+			req.Status = string(status)
+		}
+
+		if err := validate.Struct(req); err != nil {
 			validateErr := err.(validator.ValidationErrors)
 
 			log.Error("invalid request", sl.Err(err))
 
+			render.Status(r, http.StatusUnprocessableEntity)
 			render.JSON(w, r, resp.ValidationError(validateErr))
 
 			return
 		}
 
-		err = applicationStatusUpdater.UpdateApplicationStatus(id, req.Status)
+		admin := authMiddleware.Admin(r.Context())
+
+		err = applicationStatusChanger.ChangeStatus(r.Context(), id, models.Status(req.Status), admin.Login, req.Reason)
 		if err != nil {
+			if errors.Is(err, storage.ErrApplicationNotFound) {
+				log.Info("application not found", slog.Int64("id", id))
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, resp.Error(resp.CodeNotFound, "application not found"))
+				return
+			}
+			if errors.Is(err, storage.ErrProjectStatus) {
+				log.Info("unknown status", slog.String("status", req.Status))
+				render.Status(r, http.StatusUnprocessableEntity)
+				render.JSON(w, r, resp.Error(resp.CodeValidation, "status is not valid"))
+				return
+			}
+			if errors.Is(err, workflow.ErrTransitionNotAllowed) || errors.Is(err, storage.ErrStatusConflict) {
+				log.Info("status transition rejected", sl.Err(err))
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, resp.Error(resp.CodeConflict, "status transition is not allowed"))
+				return
+			}
 			log.Error("failed to update application", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to update application"))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to update application"))
 
 			return
 		}
@@ -0,0 +1,64 @@
+package dictionaries
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"slices"
+)
+
+type Response struct {
+	resp.Response
+	Locale           string  `json:"locale"`
+	Statuses         []Entry `json:"statuses"`
+	ProjectDurations []Entry `json:"project_durations"`
+	ProjectLevels    []Entry `json:"project_levels"`
+}
+
+// Entry is a value of an enum: the code the API takes and returns, and its label in the locale.
+type Entry struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+}
+
+// New returns a handler listing the values of the enums with their labels,
+// in the locale from the locale query parameter or in the default one.
+func New(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.dictionaries.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		locale := r.URL.Query().Get("locale")
+		if !slices.Contains(models.Locales, locale) {
+			locale = models.DefaultLocale
+		}
+
+		log.Debug("get dictionaries", slog.String("locale", locale))
+
+		render.JSON(w, r, Response{
+			Response:         resp.OK(),
+			Locale:           locale,
+			Statuses:         entries(models.Statuses, locale),
+			ProjectDurations: entries(models.ProjectDurations, locale),
+			ProjectLevels:    entries(models.ProjectLevels, locale),
+		})
+	}
+}
+
+func entries[E models.Enum](values []E, locale string) []Entry {
+	out := make([]Entry, len(values))
+	for i, value := range values {
+		out[i] = Entry{Code: string(value), Label: value.Label(locale)}
+	}
+
+	return out
+}
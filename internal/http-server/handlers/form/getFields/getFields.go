@@ -0,0 +1,51 @@
+package getFields
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+)
+
+type Response struct {
+	resp.Response
+	Fields []models.FormField `json:"fields"`
+}
+
+type FormFieldsGetter interface {
+	Fields(ctx context.Context) ([]models.FormField, error)
+}
+
+// New returns a handler listing the additional questions of the form, the inactive ones included.
+func New(log *slog.Logger, formFieldsGetter FormFieldsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.form.getFields.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		fields, err := formFieldsGetter.Fields(r.Context())
+		if err != nil {
+			log.Error("failed to get form fields", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get form fields"))
+
+			return
+		}
+
+		log.Info("get form fields")
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Fields:   fields,
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package getSchema
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/application"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/lib/validation"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type Response struct {
+	resp.Response
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// Field is a field of the application form. Core fields are members of the application,
+// the answers to the others go to its answers object by name.
+type Field struct {
+	Name      string           `json:"name"`
+	Label     string           `json:"label"`
+	Type      models.FieldType `json:"type"`
+	Required  bool             `json:"required"`
+	MaxLength int              `json:"max_length,omitempty"`
+	Options   []Option         `json:"options,omitempty"`
+	Core      bool             `json:"core"`
+}
+
+// Option is a value a select field accepts and its label.
+type Option struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+type ActiveFieldsGetter interface {
+	ActiveFields(ctx context.Context) ([]models.FormField, error)
+}
+
+// New returns a handler describing the application form for the frontend to render:
+// the core fields of application.Request followed by the active additional questions.
+func New(log *slog.Logger, activeFieldsGetter ActiveFieldsGetter) http.HandlerFunc {
+	core := coreFields()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.form.getSchema.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		questions, err := activeFieldsGetter.ActiveFields(r.Context())
+		if err != nil {
+			log.Error("failed to get form fields", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get form schema"))
+
+			return
+		}
+
+		fields := append([]Field{}, core...)
+		for _, question := range questions {
+			fields = append(fields, fieldOf(question))
+		}
+
+		log.Debug("get form schema", slog.Int("questions", len(questions)))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Fields:   fields,
+		})
+	}
+}
+
+// coreFields describes the string fields of application.Request by their tags.
+func coreFields() []Field {
+	var fields []Field
+
+	t := reflect.TypeOf(application.Request{})
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if structField.Type.Kind() != reflect.String {
+			continue
+		}
+
+		field := Field{
+			Name:  strings.Split(structField.Tag.Get("json"), ",")[0],
+			Label: structField.Tag.Get("label"),
+			Type:  models.FieldText,
+			Core:  true,
+		}
+		if structField.Tag.Get("form") == "textarea" {
+			field.Type = models.FieldTextarea
+		}
+
+		for _, rule := range strings.Split(structField.Tag.Get("validate"), ",") {
+			name, param, _ := strings.Cut(rule, "=")
+
+			switch name {
+			case "required":
+				field.Required = true
+			case "max":
+				field.MaxLength, _ = strconv.Atoi(param)
+			case "email":
+				field.Type = models.FieldEmail
+			case "phone":
+				field.Type = models.FieldPhone
+			case "enum":
+				enum := validation.Enums[param]
+
+				field.Type = models.FieldSelect
+				for j, code := range enum.Codes {
+					field.Options = append(field.Options, Option{Value: code, Label: enum.Labels[j]})
+				}
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+func fieldOf(question models.FormField) Field {
+	field := Field{
+		Name:     question.Name,
+		Label:    question.Label,
+		Type:     question.Type,
+		Required: question.Required,
+	}
+
+	if question.Type == models.FieldText || question.Type == models.FieldTextarea {
+		field.MaxLength = validation.MaxAnswerLength(question)
+	}
+
+	for _, option := range question.Options {
+		field.Options = append(field.Options, Option{Value: option, Label: option})
+	}
+
+	return field
+}
@@ -0,0 +1,119 @@
+package saveField
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/form"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+)
+
+// Request is the definition of an additional question. Active defaults to true.
+type Request struct {
+	Name      string   `json:"name"`
+	Label     string   `json:"label"`
+	Type      string   `json:"type"`
+	Required  bool     `json:"required"`
+	Options   []string `json:"options"`
+	MaxLength int      `json:"max_length"`
+	Position  int      `json:"position"`
+	Active    *bool    `json:"active"`
+}
+
+// Field returns the question the request defines.
+func (req Request) Field() models.FormField {
+	field := models.FormField{
+		Name:      req.Name,
+		Label:     req.Label,
+		Type:      models.FieldType(req.Type),
+		Required:  req.Required,
+		Options:   req.Options,
+		MaxLength: req.MaxLength,
+		Position:  req.Position,
+		Active:    true,
+	}
+	if req.Active != nil {
+		field.Active = *req.Active
+	}
+
+	return field
+}
+
+type Response struct {
+	resp.Response
+	Field *models.FormField `json:"field,omitempty"`
+}
+
+type FormFieldCreator interface {
+	CreateField(ctx context.Context, field models.FormField) (*models.FormField, error)
+}
+
+// New returns a handler that adds a question to the application form.
+func New(log *slog.Logger, formFieldCreator FormFieldCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.form.saveField.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "failed to decode request"))
+
+			return
+		}
+
+		field, err := formFieldCreator.CreateField(r.Context(), req.Field())
+		var invalidErr *form.InvalidFieldError
+		if errors.As(err, &invalidErr) {
+			log.Info("invalid form field", sl.Err(err))
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.Error(resp.CodeValidation, invalidErr.Reason))
+			return
+		}
+		if errors.Is(err, storage.ErrFormFieldExists) {
+			log.Info("form field already exists", slog.String("name", req.Name))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, resp.Error(resp.CodeConflict, "question with this name already exists"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to add form field", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to add form field"))
+
+			return
+		}
+
+		log.Info("form field added", slog.Int64("id", field.ID), slog.String("name", field.Name))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Field:    field,
+		})
+	}
+}
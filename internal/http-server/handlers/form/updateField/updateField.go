@@ -0,0 +1,98 @@
+package updateField
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/form"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/http-server/handlers/form/saveField"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strconv"
+)
+
+type FormFieldUpdater interface {
+	UpdateField(ctx context.Context, id int64, field models.FormField) (*models.FormField, error)
+}
+
+// New returns a handler that replaces the definition of a question of the application form.
+//
+// The request body is the same as for a new question. The name and the type may be left out,
+// they can't be changed. Questions are deactivated with "active": false instead of being deleted.
+func New(log *slog.Logger, formFieldUpdater FormFieldUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.form.updateField.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			log.Error("invalid ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
+			return
+		}
+
+		var req saveField.Request
+
+		err = render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "failed to decode request"))
+
+			return
+		}
+
+		field, err := formFieldUpdater.UpdateField(r.Context(), id, req.Field())
+		var invalidErr *form.InvalidFieldError
+		if errors.As(err, &invalidErr) {
+			log.Info("invalid form field", sl.Err(err))
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.Error(resp.CodeValidation, invalidErr.Reason))
+			return
+		}
+		if errors.Is(err, storage.ErrFormFieldNotFound) {
+			log.Info("form field not found", slog.Int64("id", id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "form field not found"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to update form field", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to update form field"))
+
+			return
+		}
+
+		log.Info("form field updated", slog.Int64("id", id))
+
+		render.JSON(w, r, saveField.Response{
+			Response: resp.OK(),
+			Field:    field,
+		})
+	}
+}
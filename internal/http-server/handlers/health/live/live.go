@@ -0,0 +1,15 @@
+package live
+
+import (
+	"github.com/go-chi/render"
+	"net/http"
+	resp "projectsShowcase/internal/lib/api/response"
+)
+
+// New returns the liveness probe handler: it answers as long as the process serves HTTP
+// and doesn't look at any dependency, so that an unavailable database doesn't get the server restarted.
+func New() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,78 @@
+package ready
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/health"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+)
+
+type Check struct {
+	Name       string  `json:"name"`
+	OK         bool    `json:"ok"`
+	Error      string  `json:"error,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+type Response struct {
+	resp.Response
+	Checks []Check `json:"checks"`
+}
+
+type Checker interface {
+	Run(ctx context.Context) ([]health.Result, bool)
+}
+
+// New returns the readiness probe handler.
+//
+// It runs the checks and answers 503 Service Unavailable if any of them fails, listing all of them.
+// The probe is public, so the errors of the failed checks, which name hosts and paths, are only logged.
+func New(log *slog.Logger, checker Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.health.ready.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		results, ok := checker.Run(r.Context())
+
+		checks := make([]Check, 0, len(results))
+		for _, result := range results {
+			check := Check{
+				Name:       result.Name,
+				OK:         result.Err == nil,
+				DurationMS: float64(result.Duration.Microseconds()) / 1000,
+			}
+			if result.Err != nil {
+				log.Warn("readiness check failed", slog.String("check", result.Name), sl.Err(result.Err))
+
+				check.Error = "check failed"
+			}
+			checks = append(checks, check)
+		}
+
+		if !ok {
+			log.Warn("server is not ready", slog.Any("checks", checks))
+
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, Response{
+				Response: resp.Error(resp.CodeUnavailable, "not ready"),
+				Checks:   checks,
+			})
+
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Checks:   checks,
+		})
+	}
+}
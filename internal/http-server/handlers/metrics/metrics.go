@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"log/slog"
+	"net/http"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"strings"
+)
+
+// New returns a handler exposing the metrics of the gatherer in the Prometheus format.
+//
+// If token is set, scrapes have to send it as a bearer token.
+func New(log *slog.Logger, gatherer prometheus.Gatherer, token string) http.HandlerFunc {
+	exposition := promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.metrics.New"
+
+		if token != "" && !authorized(r, token) {
+			log.Info("unauthorized metrics scrape",
+				slog.String("op", op),
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+				sl.TraceID(r.Context()),
+				slog.String("remote_addr", r.RemoteAddr),
+			)
+
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error(resp.CodeUnauthorized, "authentication required"))
+
+			return
+		}
+
+		exposition.ServeHTTP(w, r)
+	}
+}
+
+func authorized(r *http.Request, token string) bool {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
@@ -0,0 +1,104 @@
+package addSynonym
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/keywords"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strconv"
+)
+
+type Request struct {
+	Name string `json:"name"`
+}
+
+type SynonymAdder interface {
+	AddTagSynonym(ctx context.Context, id int64, name string) error
+}
+
+// New returns a handler that adds a synonym to a tag: keywords written with it name the tag.
+// Applications already tagged are retagged when their keywords are saved again or by "tags rebuild".
+func New(log *slog.Logger, synonymAdder SynonymAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.tags.addSynonym.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			log.Error("invalid ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
+			return
+		}
+
+		var req Request
+
+		err = render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "failed to decode request"))
+
+			return
+		}
+
+		name, ok := keywords.Term(req.Name)
+		if !ok {
+			log.Info("invalid synonym", slog.String("name", req.Name))
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.Error(resp.CodeValidation,
+				fmt.Sprintf("name must be a single keyword of up to %d characters", keywords.MaxTermLength)))
+			return
+		}
+
+		err = synonymAdder.AddTagSynonym(r.Context(), id, name)
+		if errors.Is(err, storage.ErrTagNotFound) {
+			log.Info("tag not found", slog.Int64("id", id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "tag not found"))
+			return
+		}
+		if errors.Is(err, storage.ErrTagExists) {
+			log.Info("tag already exists", slog.String("name", name))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, resp.Error(resp.CodeConflict, "tag or synonym with this name already exists, merge the tags instead"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to add tag synonym", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to add tag synonym"))
+
+			return
+		}
+
+		log.Info("tag synonym added", slog.Int64("id", id), slog.String("name", name))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
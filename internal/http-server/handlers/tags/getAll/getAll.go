@@ -0,0 +1,52 @@
+package getAll
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+)
+
+type Response struct {
+	resp.Response
+	Tags []models.Tag `json:"tags"`
+}
+
+type TagsGetter interface {
+	GetTags(ctx context.Context) ([]models.Tag, error)
+}
+
+// New returns a handler listing all tags by name with their synonyms and the number
+// of applications not in the trash having each of them, whatever their status.
+func New(log *slog.Logger, tagsGetter TagsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.tags.getAll.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		tags, err := tagsGetter.GetTags(r.Context())
+		if err != nil {
+			log.Error("failed to get tags", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get tags"))
+
+			return
+		}
+
+		log.Info("get all tags")
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Tags:     tags,
+		})
+	}
+}
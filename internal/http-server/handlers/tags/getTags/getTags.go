@@ -0,0 +1,65 @@
+package getTags
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+)
+
+type Response struct {
+	resp.Response
+	Tags []Tag `json:"tags"`
+}
+
+// Tag is an item of the list, the synonyms are for the admins only.
+type Tag struct {
+	ID    int64
+	Name  string
+	Count int
+}
+
+type ApprovedTagsGetter interface {
+	GetApprovedTags(ctx context.Context) ([]models.Tag, error)
+}
+
+// New returns a handler listing the tags of the approved applications with the number
+// of applications having each of them, the most used first. Synonyms are not listed,
+// GET /applications/approved?tag= accepts them anyway.
+func New(log *slog.Logger, approvedTagsGetter ApprovedTagsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.tags.getTags.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		tags, err := approvedTagsGetter.GetApprovedTags(r.Context())
+		if err != nil {
+			log.Error("failed to get tags", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to get tags"))
+
+			return
+		}
+
+		outTags := make([]Tag, 0, len(tags))
+		for _, tag := range tags {
+			outTags = append(outTags, Tag{ID: tag.ID, Name: tag.Name, Count: tag.Count})
+		}
+
+		log.Info("get tags")
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Tags:     outTags,
+		})
+	}
+}
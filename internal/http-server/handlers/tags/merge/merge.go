@@ -0,0 +1,100 @@
+package merge
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strconv"
+)
+
+// Request names the tag the one in the path is merged into.
+type Request struct {
+	Into int64 `json:"into"`
+}
+
+type TagMerger interface {
+	MergeTags(ctx context.Context, sourceID, targetID int64) error
+}
+
+// New returns a handler that merges the tag into another one: its applications and synonyms
+// move to the other tag, its name becomes a synonym of it and the tag is deleted.
+func New(log *slog.Logger, tagMerger TagMerger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.tags.merge.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			log.Error("invalid ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
+			return
+		}
+
+		var req Request
+
+		err = render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "failed to decode request"))
+
+			return
+		}
+
+		err = tagMerger.MergeTags(r.Context(), id, req.Into)
+		if errors.Is(err, storage.ErrTagMergeSelf) {
+			log.Info("tag merged into itself", slog.Int64("id", id))
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.Error(resp.CodeValidation, "tag can't be merged into itself"))
+			return
+		}
+		if errors.Is(err, storage.ErrTagMergeTarget) {
+			log.Info("tag to merge into not found", slog.Int64("id", id), slog.Int64("into", req.Into))
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.Error(resp.CodeValidation, "tag to merge into not found"))
+			return
+		}
+		if errors.Is(err, storage.ErrTagNotFound) {
+			log.Info("tag not found", slog.Int64("id", id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "tag not found"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to merge tags", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to merge tags"))
+
+			return
+		}
+
+		log.Info("tags merged", slog.Int64("id", id), slog.Int64("into", req.Into))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,63 @@
+package removeSynonym
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strconv"
+)
+
+type SynonymDeleter interface {
+	DeleteTagSynonym(ctx context.Context, id int64, name string) error
+}
+
+// New returns a handler that removes a synonym of a tag, given by name in the path.
+func New(log *slog.Logger, synonymDeleter SynonymDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.tags.removeSynonym.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			log.Error("invalid ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
+			return
+		}
+
+		name := chi.URLParam(r, "name")
+
+		err = synonymDeleter.DeleteTagSynonym(r.Context(), id, name)
+		if errors.Is(err, storage.ErrTagSynonymNotFound) {
+			log.Info("tag synonym not found", slog.Int64("id", id), slog.String("name", name))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "tag synonym not found"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to remove tag synonym", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to remove tag synonym"))
+
+			return
+		}
+
+		log.Info("tag synonym removed", slog.Int64("id", id), slog.String("name", name))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,104 @@
+package rename
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/keywords"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+	"strconv"
+)
+
+type Request struct {
+	Name string `json:"name"`
+}
+
+type TagRenamer interface {
+	RenameTag(ctx context.Context, id int64, name string) error
+}
+
+// New returns a handler that renames a tag. The old name becomes a synonym of the tag,
+// so the keywords written with it keep naming the tag.
+func New(log *slog.Logger, tagRenamer TagRenamer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.tags.rename.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			log.Error("invalid ID format", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "invalid ID format"))
+			return
+		}
+
+		var req Request
+
+		err = render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.CodeBadRequest, "failed to decode request"))
+
+			return
+		}
+
+		name, ok := keywords.Term(req.Name)
+		if !ok {
+			log.Info("invalid tag name", slog.String("name", req.Name))
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp.Error(resp.CodeValidation,
+				fmt.Sprintf("name must be a single keyword of up to %d characters", keywords.MaxTermLength)))
+			return
+		}
+
+		err = tagRenamer.RenameTag(r.Context(), id, name)
+		if errors.Is(err, storage.ErrTagNotFound) {
+			log.Info("tag not found", slog.Int64("id", id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, resp.Error(resp.CodeNotFound, "tag not found"))
+			return
+		}
+		if errors.Is(err, storage.ErrTagExists) {
+			log.Info("tag already exists", slog.String("name", name))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, resp.Error(resp.CodeConflict, "tag or synonym with this name already exists, merge the tags instead"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to rename tag", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to rename tag"))
+
+			return
+		}
+
+		log.Info("tag renamed", slog.Int64("id", id), slog.String("name", name))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"projectsShowcase/internal/domain/auth"
+	"projectsShowcase/internal/domain/models"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/storage"
+)
+
+// CookieName is the name of the cookie holding the admin session token.
+const CookieName = "admin_session"
+
+type ctxKey struct{}
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*models.AdminUser, error)
+	CheckPassword(ctx context.Context, login, password string) (*models.AdminUser, error)
+}
+
+// New returns a middleware that only lets signed-in admins through and stores the admin in the request context.
+//
+// Admins are identified by the session cookie set on login or, for scripts, by HTTP Basic credentials.
+func New(log *slog.Logger, authenticator Authenticator) func(next http.Handler) http.Handler {
+	log = log.With(
+		slog.String("component", "middleware/auth"),
+	)
+
+	log.Info("auth middleware enabled")
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			entry := log.With(
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+				sl.TraceID(r.Context()),
+			)
+
+			admin, err := authenticate(r, authenticator)
+			if err != nil {
+				if errors.Is(err, auth.ErrInvalidCredentials) ||
+					errors.Is(err, auth.ErrSessionExpired) ||
+					errors.Is(err, storage.ErrSessionNotFound) ||
+					errors.Is(err, http.ErrNoCookie) {
+					entry.Info("admin is not authenticated", sl.Err(err))
+
+					if _, _, ok := r.BasicAuth(); ok {
+						w.Header().Set("WWW-Authenticate", `Basic realm="projects-showcase"`)
+					}
+
+					render.Status(r, http.StatusUnauthorized)
+					render.JSON(w, r, resp.Error(resp.CodeUnauthorized, "authentication required"))
+
+					return
+				}
+
+				entry.Error("failed to authenticate admin", sl.Err(err))
+
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error(resp.CodeInternal, "failed to authenticate"))
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKey{}, admin)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// RequireRole returns a middleware that only lets through admins whose role allows what the given role may.
+//
+// It has to be used after New.
+func RequireRole(role string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			admin := Admin(r.Context())
+			if admin == nil || !auth.Allows(admin.Role, role) {
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, resp.Error(resp.CodeForbidden, "the "+role+" role is required"))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// Admin returns the admin stored in the context by the middleware, or nil.
+func Admin(ctx context.Context) *models.AdminUser {
+	admin, _ := ctx.Value(ctxKey{}).(*models.AdminUser)
+
+	return admin
+}
+
+func authenticate(r *http.Request, authenticator Authenticator) (*models.AdminUser, error) {
+	if login, password, ok := r.BasicAuth(); ok {
+		return authenticator.CheckPassword(r.Context(), login, password)
+	}
+
+	cookie, err := r.Cookie(CookieName)
+	if err != nil {
+		return nil, err
+	}
+
+	return authenticator.Authenticate(r.Context(), cookie.Value)
+}
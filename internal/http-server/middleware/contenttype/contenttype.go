@@ -0,0 +1,30 @@
+package contenttype
+
+import (
+	"github.com/go-chi/render"
+	"mime"
+	"net/http"
+	resp "projectsShowcase/internal/lib/api/response"
+)
+
+// Route returns a handler that passes the request to the handler registered for its media type.
+//
+// Requests with other media types go to fallback, or are rejected with 415 Unsupported Media Type if it is nil.
+func Route(handlers map[string]http.Handler, fallback http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+		if h, ok := handlers[mediaType]; ok {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		if fallback != nil {
+			fallback.ServeHTTP(w, r)
+			return
+		}
+
+		render.Status(r, http.StatusUnsupportedMediaType)
+		render.JSON(w, r, resp.Error(resp.CodeUnsupportedMediaType, "unsupported content type"))
+	}
+}
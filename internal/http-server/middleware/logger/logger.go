@@ -4,12 +4,13 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"log/slog"
 	"net/http"
+	"projectsShowcase/internal/lib/logger/sl"
 	"time"
 )
 
 func New(log *slog.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		log = log.With(
+		log := log.With(
 			slog.String("component", "middleware/logger"),
 		)
 
@@ -22,6 +23,7 @@ func New(log *slog.Logger) func(next http.Handler) http.Handler {
 				slog.String("remote_addr", r.RemoteAddr),
 				slog.String("user_agent", r.UserAgent()),
 				slog.String("request_id", middleware.GetReqID(r.Context())),
+				sl.TraceID(r.Context()),
 			)
 
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
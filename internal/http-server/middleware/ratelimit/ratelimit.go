@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	resp "projectsShowcase/internal/lib/api/response"
+	"projectsShowcase/internal/lib/logger/sl"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Limiter interface {
+	Allow(key string) (bool, time.Duration)
+}
+
+// KeyFunc returns the key the request is limited by.
+type KeyFunc func(r *http.Request) string
+
+// New returns a middleware that answers 429 with a Retry-After header
+// once the requests sharing a key run out of tokens.
+func New(log *slog.Logger, limiter Limiter, key KeyFunc) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/ratelimit"),
+		)
+
+		log.Info("rate limit middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			k := key(r)
+
+			if ok, retryAfter := limiter.Allow(k); !ok {
+				log.Warn("request rate limited",
+					slog.String("request_id", middleware.GetReqID(r.Context())),
+					sl.TraceID(r.Context()),
+					slog.String("key", k),
+				)
+
+				TooManyRequests(w, r, retryAfter)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// TooManyRequests answers 429 telling the client to retry after the given time.
+func TooManyRequests(w http.ResponseWriter, r *http.Request, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+
+	render.Status(r, http.StatusTooManyRequests)
+	render.JSON(w, r, resp.Error(resp.CodeTooManyRequests, "too many requests, try again later"))
+}
+
+// ByIP limits the requests by client address. IPv6 clients are limited by their /64 network,
+// which usually belongs to a single subscriber.
+//
+// trustProxy takes the address from the X-Real-IP header or else the last X-Forwarded-For entry,
+// which is the one added by the proxy. It must only be set behind a reverse proxy that sets them,
+// otherwise clients pick their own keys.
+func ByIP(trustProxy bool) KeyFunc {
+	return func(r *http.Request) string {
+		addr := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+
+		if trustProxy {
+			if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+				addr = realIP
+			} else if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+				addr = strings.TrimSpace(forwarded[strings.LastIndex(forwarded, ",")+1:])
+			}
+		}
+
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return addr
+		}
+		if ip.To4() == nil {
+			return ip.Mask(net.CIDRMask(64, 128)).String() + "/64"
+		}
+
+		return ip.String()
+	}
+}
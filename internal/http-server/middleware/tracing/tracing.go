@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"net/http"
+)
+
+// New returns a middleware that starts a server span for every request, continuing the trace
+// of the caller if it sent one. Requests to skipPaths, such as the probes, aren't traced.
+//
+// It has to be used on the root router before the other middlewares, so that their time is part of the span,
+// and the span is named after the chi route pattern once the request has been routed.
+func New(log *slog.Logger, skipPaths ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/tracing"),
+		)
+
+		log.Info("tracing middleware enabled")
+
+		skip := make(map[string]bool, len(skipPaths))
+		for _, path := range skipPaths {
+			skip[path] = true
+		}
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+
+			rctx := chi.RouteContext(r.Context())
+			if rctx == nil || rctx.RoutePattern() == "" {
+				return
+			}
+
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(r.Method + " " + rctx.RoutePattern())
+			span.SetAttributes(semconv.HTTPRoute(rctx.RoutePattern()))
+		}
+
+		return otelhttp.NewHandler(http.HandlerFunc(fn), "HTTP",
+			otelhttp.WithFilter(func(r *http.Request) bool {
+				return !skip[r.URL.Path]
+			}),
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return r.Method
+			}),
+		)
+	}
+}
@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"projectsShowcase/internal/domain/models"
+	"strings"
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+// Parse reads the filter and sort parameters of the admin application lists:
+//   - status, project_level, project_duration: filters by codes (labels are accepted too),
+//     repeat the parameter or separate values with commas;
+//   - tag: applications tagged with the tag, by its name or a synonym;
+//   - submitted_from, submitted_to: submission date range, YYYY-MM-DD (inclusive) or RFC 3339;
+//   - q: full-text search over the project name, goal, barrier, existing solutions and keywords;
+//   - sort: status, submission_date, project_name, project_level, id or relevance (search only),
+//     prefixed with "-" for descending order. Search results are sorted by relevance by default.
+func Parse(values url.Values) (models.ApplicationQuery, error) {
+	query := models.ApplicationQuery{
+		Search: strings.TrimSpace(values.Get("q")),
+		Tag:    strings.TrimSpace(values.Get("tag")),
+		SortBy: models.SortByStatus,
+	}
+
+	var err error
+
+	if query.Statuses, err = enums(values, "status", models.Statuses); err != nil {
+		return models.ApplicationQuery{}, err
+	}
+	if query.ProjectLevels, err = enums(values, "project_level", models.ProjectLevels); err != nil {
+		return models.ApplicationQuery{}, err
+	}
+	if query.ProjectDurations, err = enums(values, "project_duration", models.ProjectDurations); err != nil {
+		return models.ApplicationQuery{}, err
+	}
+
+	if query.Search != "" {
+		query.SortBy = models.SortByRelevance
+	}
+
+	if v := values.Get("submitted_from"); v != "" {
+		from, _, err := parseTime(v)
+		if err != nil {
+			return models.ApplicationQuery{}, fmt.Errorf("submitted_from is not a valid date: %s", v)
+		}
+		query.SubmittedFrom = from
+	}
+
+	if v := values.Get("submitted_to"); v != "" {
+		to, dateOnly, err := parseTime(v)
+		if err != nil {
+			return models.ApplicationQuery{}, fmt.Errorf("submitted_to is not a valid date: %s", v)
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		query.SubmittedTo = to
+	}
+
+	if v := values.Get("sort"); v != "" {
+		field := strings.TrimPrefix(v, "-")
+		if !models.IsSortField(field) {
+			return models.ApplicationQuery{}, fmt.Errorf("applications cannot be sorted by %s", field)
+		}
+		if field == models.SortByRelevance && query.Search == "" {
+			return models.ApplicationQuery{}, errors.New("sorting by relevance requires a search query")
+		}
+		query.SortBy = field
+		query.SortDesc = strings.HasPrefix(v, "-")
+	}
+
+	return query, nil
+}
+
+// list returns all values of the parameter, splitting comma-separated ones.
+func list(values url.Values, key string) []string {
+	var result []string
+
+	for _, value := range values[key] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+
+	return result
+}
+
+// enums returns the enum values of the parameter, see list.
+func enums[E models.Enum](values url.Values, key string, all []E) ([]E, error) {
+	var result []E
+
+	for _, item := range list(values, key) {
+		value, ok := models.Parse(all, item)
+		if !ok {
+			return nil, fmt.Errorf("%s must be one of %s: %s", key, strings.Join(models.Codes(all), ", "), item)
+		}
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+// parseTime parses a date or an RFC 3339 timestamp and reports whether the value was a date.
+func parseTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(dateFormat, value); err == nil {
+		return t, true, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+
+	return t, false, err
+}
@@ -0,0 +1,89 @@
+package paging
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultSize = 50
+	MaxSize     = 200
+)
+
+var (
+	ErrInvalidPage = errors.New("page must be a positive integer")
+	ErrInvalidSize = errors.New("per_page must be a positive integer")
+)
+
+// Page is a 1-based page number and the number of items per page.
+type Page struct {
+	Number int
+	Size   int
+}
+
+// Parse reads the page and per_page query parameters of the request.
+//
+// Missing parameters default to the first page of DefaultSize items, per_page is capped at MaxSize.
+func Parse(r *http.Request) (Page, error) {
+	page := Page{Number: 1, Size: DefaultSize}
+
+	if v := r.URL.Query().Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return Page{}, ErrInvalidPage
+		}
+		page.Number = n
+	}
+
+	if v := r.URL.Query().Get("per_page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return Page{}, ErrInvalidSize
+		}
+		page.Size = min(n, MaxSize)
+	}
+
+	return page, nil
+}
+
+// Offset returns the number of items before the page.
+func (p Page) Offset() int {
+	return (p.Number - 1) * p.Size
+}
+
+// Last returns the number of the last page for total items.
+func (p Page) Last(total int) int {
+	if total == 0 {
+		return 1
+	}
+
+	return (total + p.Size - 1) / p.Size
+}
+
+// Link returns the value of the Link header (RFC 8288) with first, prev, next and last
+// relations for the page of the request.
+func Link(r *http.Request, p Page, total int) string {
+	last := p.Last(total)
+
+	links := []string{link(r, p, 1, "first")}
+	if p.Number > 1 {
+		links = append(links, link(r, p, min(p.Number-1, last), "prev"))
+	}
+	if p.Number < last {
+		links = append(links, link(r, p, p.Number+1, "next"))
+	}
+	links = append(links, link(r, p, last, "last"))
+
+	return strings.Join(links, ", ")
+}
+
+func link(r *http.Request, p Page, number int, rel string) string {
+	query := r.URL.Query()
+	query.Set("page", strconv.Itoa(number))
+	query.Set("per_page", strconv.Itoa(p.Size))
+
+	return fmt.Sprintf(`<%s?%s>; rel="%s"`, r.URL.Path, query.Encode(), rel)
+}
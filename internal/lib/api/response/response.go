@@ -3,12 +3,23 @@ package response
 import (
 	"fmt"
 	"github.com/go-playground/validator/v10"
+	"projectsShowcase/internal/lib/validation"
+	"strconv"
 	"strings"
 )
 
 type Response struct {
-	Status string `json:"status"`
-	Error  string `json:"error,omitempty"`
+	Status  string       `json:"status"`
+	Code    string       `json:"code,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError describes why a single request field is not valid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
 }
 
 const (
@@ -16,9 +27,27 @@ const (
 	StatusError = "Error"
 )
 
-func Error(msg string) Response {
+// Machine-readable error codes, each one is sent with its own HTTP status.
+const (
+	CodeBadRequest           = "bad_request"            // 400
+	CodeUnauthorized         = "unauthorized"           // 401
+	CodeForbidden            = "forbidden"              // 403
+	CodeNotFound             = "not_found"              // 404
+	CodeConflict             = "conflict"               // 409
+	CodePreconditionFailed   = "precondition_failed"    // 412
+	CodePayloadTooLarge      = "payload_too_large"      // 413
+	CodeUnsupportedMediaType = "unsupported_media_type" // 415
+	CodeValidation           = "validation_failed"      // 422
+	CodePreconditionRequired = "precondition_required"  // 428
+	CodeTooManyRequests      = "too_many_requests"      // 429
+	CodeInternal             = "internal_error"         // 500
+	CodeUnavailable          = "unavailable"            // 503
+)
+
+func Error(code, msg string) Response {
 	return Response{
 		Status: StatusError,
+		Code:   code,
 		Error:  msg,
 	}
 }
@@ -29,20 +58,132 @@ func OK() Response {
 	}
 }
 
+// ValidationError describes the failed rules in Russian, the messages are shown to the applicants as they are.
+//
+// Fields are named after their JSON names and the messages after their labels
+// if the validator comes from validation.New.
 func ValidationError(errs validator.ValidationErrors) Response {
-	var errMsgs []string
+	var (
+		errMsgs []string
+		details []FieldError
+	)
 
 	for _, err := range errs {
-		switch err.ActualTag() {
-		case "required":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is a required field", err.Field()))
-		default:
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not valid", err.Field()))
-		}
+		msg := message(err)
+
+		errMsgs = append(errMsgs, msg)
+		details = append(details, FieldError{
+			Field:   err.Field(),
+			Rule:    err.ActualTag(),
+			Message: msg,
+		})
 	}
 
 	return Response{
-		Status: StatusError,
-		Error:  strings.Join(errMsgs, ", "),
+		Status:  StatusError,
+		Code:    CodeValidation,
+		Error:   strings.Join(errMsgs, "; "),
+		Details: details,
+	}
+}
+
+// AnswersError describes the failed rules of the answers to the additional questions in Russian,
+// the same way ValidationError does. Fields are named answers.<question name>.
+func AnswersError(errs validation.AnswerErrors) Response {
+	var (
+		errMsgs []string
+		details []FieldError
+	)
+
+	for _, err := range errs {
+		msg := ruleMessage(err.Rule, err.Label, err.Param)
+
+		errMsgs = append(errMsgs, msg)
+		details = append(details, FieldError{
+			Field:   "answers." + err.Name,
+			Rule:    err.Rule,
+			Message: msg,
+		})
+	}
+
+	return Response{
+		Status:  StatusError,
+		Code:    CodeValidation,
+		Error:   strings.Join(errMsgs, "; "),
+		Details: details,
+	}
+}
+
+// AttachmentError describes a rejected attachment in Russian, the same way ValidationError does.
+// The field is attachments, the rule is max for too many files, filesize or filetype.
+// Limit is the number of files or the file size in MB that was exceeded.
+func AttachmentError(rule, file string, limit int64) Response {
+	var msg string
+
+	switch rule {
+	case "max":
+		msg = fmt.Sprintf("можно прикрепить не более %d %s", limit, genitive(limit, "файла", "файлов"))
+	case "filesize":
+		msg = fmt.Sprintf("файл «%s» больше %d МБ", file, limit)
+	default:
+		msg = fmt.Sprintf("файл «%s» имеет недопустимый тип, прикрепите PDF, DOCX или изображение", file)
+	}
+
+	return Response{
+		Status:  StatusError,
+		Code:    CodeValidation,
+		Error:   msg,
+		Details: []FieldError{{Field: "attachments", Rule: rule, Message: msg}},
+	}
+}
+
+func message(err validator.FieldError) string {
+	if err.ActualTag() == "enum" {
+		return ruleMessage("oneof", validation.Label(err), strings.Join(validation.Enums[err.Param()].Labels, ", "))
 	}
+
+	return ruleMessage(err.ActualTag(), validation.Label(err), err.Param())
+}
+
+// ruleMessage returns the message of a failed rule of the field with the given label.
+func ruleMessage(rule, field, param string) string {
+	switch rule {
+	case "required":
+		return fmt.Sprintf("поле «%s» обязательно для заполнения", field)
+	case "email":
+		return fmt.Sprintf("поле «%s» должно содержать адрес электронной почты, например name@example.com", field)
+	case "phone":
+		return fmt.Sprintf("поле «%s» должно содержать номер телефона, например +7 999 123-45-67", field)
+	case "max":
+		return fmt.Sprintf("поле «%s» должно быть не длиннее %s %s", field, param, characters(param))
+	case "min":
+		return fmt.Sprintf("поле «%s» должно быть не короче %s %s", field, param, characters(param))
+	case "oneof":
+		return fmt.Sprintf("поле «%s» должно иметь одно из значений: %s", field, param)
+	case "number":
+		return fmt.Sprintf("поле «%s» должно содержать число", field)
+	case "unknown":
+		return fmt.Sprintf("в форме нет вопроса «%s»", field)
+	default:
+		return fmt.Sprintf("поле «%s» заполнено неверно", field)
+	}
+}
+
+// characters returns "символ" in the genitive case agreeing with the number: "не длиннее 21 символа", "не длиннее 200 символов".
+func characters(number string) string {
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return "символов"
+	}
+
+	return genitive(n, "символа", "символов")
+}
+
+// genitive returns the genitive singular form after numbers ending in 1 except 11 and the plural form otherwise.
+func genitive(n int64, singular, plural string) string {
+	if n%10 == 1 && n%100 != 11 {
+		return singular
+	}
+
+	return plural
 }
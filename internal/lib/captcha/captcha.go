@@ -0,0 +1,95 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrFailed means the token is missing or the CAPTCHA provider rejected it.
+var ErrFailed = errors.New("captcha verification failed")
+
+// FakeToken is the only token the Fake verifier accepts.
+const FakeToken = "fake-captcha-token"
+
+// None accepts every submission, it is used when no CAPTCHA is configured.
+type None struct{}
+
+func (None) Verify(_ context.Context, _ string) error {
+	return nil
+}
+
+// Fake checks tokens locally, for tests and development: FakeToken passes, anything else fails.
+type Fake struct{}
+
+func (Fake) Verify(_ context.Context, token string) error {
+	if token != FakeToken {
+		return ErrFailed
+	}
+
+	return nil
+}
+
+// SiteVerify checks tokens with the siteverify API shared by Cloudflare Turnstile, hCaptcha and reCAPTCHA:
+// the secret and the token are posted as a form and the JSON answer tells whether the token is valid.
+type SiteVerify struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewSiteVerify(verifyURL, secret string, timeout time.Duration) *SiteVerify {
+	return &SiteVerify{
+		url:    verifyURL,
+		secret: secret,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (v *SiteVerify) Verify(ctx context.Context, token string) error {
+	const op = "captcha.SiteVerify.Verify"
+
+	if token == "" {
+		return ErrFailed
+	}
+
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {token},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status %s", op, res.Status)
+	}
+
+	var answer struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&answer); err != nil {
+		return fmt.Errorf("%s: decode answer: %w", op, err)
+	}
+
+	if !answer.Success {
+		return fmt.Errorf("%s: %s: %w", op, strings.Join(answer.ErrorCodes, ", "), ErrFailed)
+	}
+
+	return nil
+}
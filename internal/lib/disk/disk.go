@@ -0,0 +1,6 @@
+package disk
+
+import "errors"
+
+// ErrUnsupported is returned by Free on systems it can't query.
+var ErrUnsupported = errors.New("free disk space is not available on this system")
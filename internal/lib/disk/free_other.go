@@ -0,0 +1,8 @@
+//go:build !(linux || darwin || freebsd)
+
+package disk
+
+// Free returns ErrUnsupported, free space is only queried on Linux, macOS and FreeBSD.
+func Free(path string) (uint64, error) {
+	return 0, ErrUnsupported
+}
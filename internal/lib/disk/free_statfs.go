@@ -0,0 +1,18 @@
+//go:build linux || darwin || freebsd
+
+package disk
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Free returns the number of bytes available to unprivileged users on the file system of path.
+func Free(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, fmt.Errorf("statfs %s: %w", path, err)
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
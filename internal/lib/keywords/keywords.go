@@ -0,0 +1,64 @@
+package keywords
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Split returns the terms of a keywords field as they are written, without repeats by Key.
+// Terms are separated by commas, semicolons or new lines, surrounding spaces and dots are dropped.
+func Split(keywords string) []string {
+	parts := strings.FieldsFunc(keywords, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n' || r == '\r'
+	})
+
+	var (
+		terms []string
+		seen  = map[string]bool{}
+	)
+
+	for _, part := range parts {
+		term := strings.Join(strings.Fields(strings.Trim(part, " \t.")), " ")
+
+		key := Key(term)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		terms = append(terms, term)
+	}
+
+	return terms
+}
+
+// Key returns the form of a term tags are matched by: lower case, with single spaces and "ё" read as "е",
+// so that "ИИ", "ии" and " ИИ " are the same tag.
+func Key(term string) string {
+	term = strings.Join(strings.Fields(term), " ")
+	term = strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if r == 'ё' {
+			return 'е'
+		}
+
+		return r
+	}, term)
+
+	return term
+}
+
+// MaxTermLength is the length limit of tag names and synonyms set by the admins.
+const MaxTermLength = 100
+
+// Term returns the name as a single term of a keywords field, as Split would write it.
+// It reports false if the name is empty, too long or holds several terms.
+func Term(name string) (string, bool) {
+	terms := Split(name)
+	if len(terms) != 1 || utf8.RuneCountInString(terms[0]) > MaxTermLength {
+		return "", false
+	}
+
+	return terms[0], true
+}
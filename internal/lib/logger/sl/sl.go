@@ -1,7 +1,10 @@
 package sl
 
 import (
+	"context"
 	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 func Err(err error) slog.Attr {
@@ -10,3 +13,14 @@ func Err(err error) slog.Attr {
 		Value: slog.StringValue(err.Error()),
 	}
 }
+
+// TraceID returns the ID of the trace the context belongs to.
+// When the request isn't traced the attribute is empty and slog leaves it out.
+func TraceID(ctx context.Context) slog.Attr {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.HasTraceID() {
+		return slog.Attr{}
+	}
+
+	return slog.String("trace_id", spanCtx.TraceID().String())
+}
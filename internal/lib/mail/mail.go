@@ -0,0 +1,171 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidAddress = errors.New("invalid email address")
+
+// Message is a plain text email to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// SMTPSender sends messages through an SMTP server.
+//
+// STARTTLS is used if the server supports it, credentials are only sent if a username is set.
+type SMTPSender struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     *mail.Address
+	timeout  time.Duration
+}
+
+// NewSMTPSender creates a sender for the server at host:port.
+//
+// from is the sender address, optionally with a display name: "Витрина проектов <noreply@example.com>".
+func NewSMTPSender(host string, port int, username, password, from string, timeout time.Duration) (*SMTPSender, error) {
+	const op = "mail.NewSMTPSender"
+
+	fromAddr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("%s: parse from address: %w", op, err)
+	}
+
+	return &SMTPSender{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     fromAddr,
+		timeout:  timeout,
+	}, nil
+}
+
+// Send delivers the message, the whole SMTP conversation has to fit into the sender timeout.
+func (s *SMTPSender) Send(msg Message) error {
+	const op = "mail.SMTPSender.Send"
+
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("%s: %q: %w", op, msg.To, ErrInvalidAddress)
+	}
+
+	data, err := s.compose(to, msg)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(s.host, strconv.Itoa(s.port)), s.timeout)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("%s: starttls: %w", op, err)
+		}
+	}
+
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("%s: auth: %w", op, err)
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("%s: mail from: %w", op, err)
+	}
+
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("%s: rcpt to: %w", op, err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("%s: data: %w", op, err)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("%s: write message: %w", op, err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("%s: write message: %w", op, err)
+	}
+
+	return client.Quit()
+}
+
+// compose renders the message headers and the quoted-printable UTF-8 body.
+func (s *SMTPSender) compose(to *mail.Address, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	messageID, err := s.messageID()
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(&buf, "From: %s\r\n", s.from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: %s\r\n", messageID)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write(bytes.ReplaceAll([]byte(msg.Body), []byte("\n"), []byte("\r\n"))); err != nil {
+		return nil, err
+	}
+
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *SMTPSender) messageID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	domain := s.host
+	if i := strings.LastIndex(s.from.Address, "@"); i >= 0 {
+		domain = s.from.Address[i+1:]
+	}
+
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">", nil
+}
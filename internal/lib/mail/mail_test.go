@@ -0,0 +1,169 @@
+package mail
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// received is what the fake server got in one SMTP session.
+type received struct {
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTP accepts a single session on a local port and reports what it received.
+//
+// It speaks just enough SMTP for net/smtp: no STARTTLS and no AUTH are advertised.
+func fakeSMTP(t *testing.T) (host string, port int, result <-chan received) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	ch := make(chan received, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		var rcv received
+
+		tp.PrintfLine("220 localhost ESMTP fake")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"):
+				tp.PrintfLine("250-localhost")
+				tp.PrintfLine("250 8BITMIME")
+			case strings.HasPrefix(cmd, "HELO"):
+				tp.PrintfLine("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				rcv.from = path(line[len("MAIL FROM:"):])
+				tp.PrintfLine("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				rcv.to = append(rcv.to, path(line[len("RCPT TO:"):]))
+				tp.PrintfLine("250 OK")
+			case cmd == "DATA":
+				tp.PrintfLine("354 go ahead")
+				data, err := io.ReadAll(tp.DotReader())
+				if err != nil {
+					return
+				}
+				rcv.data = string(data)
+				tp.PrintfLine("250 queued")
+			case cmd == "QUIT":
+				tp.PrintfLine("221 bye")
+				ch <- rcv
+				return
+			default:
+				tp.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+
+	return addr.IP.String(), addr.Port, ch
+}
+
+// path returns the address from a "<addr> PARAMS" command argument.
+func path(arg string) string {
+	arg = strings.TrimPrefix(strings.TrimSpace(arg), "<")
+	addr, _, _ := strings.Cut(arg, ">")
+
+	return addr
+}
+
+func TestSMTPSenderSend(t *testing.T) {
+	host, port, result := fakeSMTP(t)
+
+	sender, err := NewSMTPSender(host, port, "", "", "Витрина проектов <noreply@example.com>", 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewSMTPSender: %v", err)
+	}
+
+	err = sender.Send(Message{
+		To:      "student@example.com",
+		Subject: "Заявка принята",
+		Body:    "Здравствуйте!\nВаша заявка принята.",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	var rcv received
+	select {
+	case rcv = <-result:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake server received nothing")
+	}
+
+	if rcv.from != "noreply@example.com" {
+		t.Errorf("MAIL FROM = %q, want %q", rcv.from, "noreply@example.com")
+	}
+
+	if len(rcv.to) != 1 || rcv.to[0] != "student@example.com" {
+		t.Errorf("RCPT TO = %q, want [student@example.com]", rcv.to)
+	}
+
+	msg, err := textproto.NewReader(bufio.NewReader(strings.NewReader(rcv.data))).ReadMIMEHeader()
+	if err != nil {
+		t.Fatalf("read headers: %v", err)
+	}
+
+	if got := msg.Get("To"); got != "<student@example.com>" {
+		t.Errorf("To = %q, want %q", got, "<student@example.com>")
+	}
+
+	if got := msg.Get("Content-Type"); got != "text/plain; charset=UTF-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+
+	if !strings.HasSuffix(msg.Get("Message-ID"), "@example.com>") {
+		t.Errorf("Message-ID = %q, want the sender domain", msg.Get("Message-ID"))
+	}
+
+	// DotReader hands back the message with bare \n line endings.
+	_, body, _ := strings.Cut(rcv.data, "\n\n")
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+	if err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+
+	got := strings.ReplaceAll(strings.TrimRight(string(decoded), "\r\n"), "\r\n", "\n")
+	if want := "Здравствуйте!\nВаша заявка принята."; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestSMTPSenderSendInvalidAddress(t *testing.T) {
+	sender, err := NewSMTPSender("127.0.0.1", 1, "", "", "noreply@example.com", time.Second)
+	if err != nil {
+		t.Fatalf("NewSMTPSender: %v", err)
+	}
+
+	err = sender.Send(Message{To: "not an address"})
+	if !errors.Is(err, ErrInvalidAddress) {
+		t.Fatalf("Send error = %v, want ErrInvalidAddress", err)
+	}
+}
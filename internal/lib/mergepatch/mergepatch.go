@@ -0,0 +1,67 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var ErrNotObject = errors.New("merge patch document must be a JSON object")
+
+// Apply applies a JSON Merge Patch (RFC 7396) to the JSON document doc and returns the result.
+//
+// A patch that is not an object replaces the whole document, see Keys to accept object patches only.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var target any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+// Keys returns the top-level member names of the merge patch or ErrNotObject if the patch is not an object.
+func Keys(patch []byte) ([]string, error) {
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+
+	patchObject, ok := p.(map[string]any)
+	if !ok {
+		return nil, ErrNotObject
+	}
+
+	keys := make([]string, 0, len(patchObject))
+	for key := range patchObject {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func merge(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = merge(targetObject[key], value)
+	}
+
+	return targetObject
+}
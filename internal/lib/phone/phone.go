@@ -0,0 +1,53 @@
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalid = errors.New("invalid phone number")
+
+// Normalize returns the phone number in the E.164 format: "+" and up to 15 digits.
+//
+// Russian numbers may be written the way people write them: with 8 or 7 instead of +7,
+// or without the country code at all, and with spaces, dashes, dots and parentheses.
+// Foreign numbers have to start with "+" and their country code.
+func Normalize(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	international := strings.HasPrefix(s, "+")
+	if international {
+		s = s[1:]
+	}
+
+	var digits strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", ErrInvalid
+		}
+	}
+	number := digits.String()
+
+	switch {
+	case international && strings.HasPrefix(number, "7"):
+		if len(number) != 11 {
+			return "", ErrInvalid
+		}
+	case international:
+		if len(number) < 8 || len(number) > 15 || number[0] == '0' {
+			return "", ErrInvalid
+		}
+	case len(number) == 11 && (number[0] == '8' || number[0] == '7'):
+		number = "7" + number[1:]
+	case len(number) == 10:
+		number = "7" + number
+	default:
+		return "", ErrInvalid
+	}
+
+	return "+" + number, nil
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter is a set of token buckets, one per key.
+//
+// Every bucket holds up to burst tokens, each allowed request takes one,
+// and a token comes back every interval. A limiter with no burst allows everything.
+type Limiter struct {
+	every time.Duration
+	burst int
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func New(every time.Duration, burst int) *Limiter {
+	return &Limiter{
+		every:     every,
+		burst:     burst,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow takes a token from the bucket of the key. If the bucket is empty,
+// it reports false and how long it takes until the next token.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l.burst <= 0 {
+		return true, 0
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = min(float64(l.burst), b.tokens+float64(now.Sub(b.last))/float64(l.every))
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+
+		return true, 0
+	}
+
+	return false, time.Duration((1 - b.tokens) * float64(l.every))
+}
+
+// sweep forgets the buckets that have filled up again, they are no different from new ones.
+// It runs at most once per refill time so that Allow stays cheap.
+func (l *Limiter) sweep(now time.Time) {
+	refill := l.every * time.Duration(l.burst)
+	if now.Sub(l.lastSweep) < refill {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+
+	l.lastSweep = now
+}
@@ -0,0 +1,77 @@
+package signedtoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("token is not valid")
+	ErrTokenExpired = errors.New("token has expired")
+)
+
+// Signer issues and verifies tokens carrying a subject and an expiry time, signed with HMAC-SHA256.
+//
+// Tokens are not encrypted, the subject must not be secret.
+// The purpose is signed along with the token, so tokens issued for one purpose are rejected for another.
+type Signer struct {
+	key     []byte
+	purpose string
+}
+
+func New(key []byte, purpose string) *Signer {
+	return &Signer{key: key, purpose: purpose}
+}
+
+// Sign returns a URL-safe token for the subject valid until expiresAt.
+//
+// The token is the base64url encoding of "<subject>.<expiry unix time>" followed by its MAC.
+func (s *Signer) Sign(subject string, expiresAt time.Time) string {
+	payload := subject + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+
+	return base64.RawURLEncoding.EncodeToString(append([]byte(payload), s.mac(payload)...))
+}
+
+// Verify checks the token signature and expiry at now and returns its subject.
+func (s *Signer) Verify(token string, now time.Time) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) <= sha256.Size {
+		return "", ErrInvalidToken
+	}
+
+	payload, mac := raw[:len(raw)-sha256.Size], raw[len(raw)-sha256.Size:]
+
+	if !hmac.Equal(mac, s.mac(string(payload))) {
+		return "", ErrInvalidToken
+	}
+
+	i := strings.LastIndex(string(payload), ".")
+	if i < 0 {
+		return "", ErrInvalidToken
+	}
+
+	expiresAt, err := strconv.ParseInt(string(payload[i+1:]), 10, 64)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+
+	if now.Unix() >= expiresAt {
+		return "", ErrTokenExpired
+	}
+
+	return string(payload[:i]), nil
+}
+
+func (s *Signer) mac(payload string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(s.purpose))
+	h.Write([]byte{0})
+	h.Write([]byte(payload))
+
+	return h.Sum(nil)
+}
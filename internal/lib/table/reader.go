@@ -0,0 +1,150 @@
+package table
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var ErrEmptyWorkbook = errors.New("workbook has no sheets")
+
+// Reader reads a table row by row, ReadRow returns io.EOF after the last row.
+type Reader interface {
+	ReadRow() ([]string, error)
+	Close() error
+}
+
+// FormatOf returns the table format of a file by its extension or an empty string if it is not a table.
+func FormatOf(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return FormatCSV
+	case ".xlsx":
+		return FormatXLSX
+	default:
+		return ""
+	}
+}
+
+// NewReader returns a reader of a table in the given format.
+func NewReader(format string, r io.Reader) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVReader(r)
+	case FormatXLSX:
+		return NewXLSXReader(r)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+type csvReader struct {
+	r *csv.Reader
+}
+
+// NewCSVReader returns a reader of a UTF-8 CSV table with an optional byte order mark.
+//
+// Fields are separated with commas or, as Excel saves them in the Russian locale, with semicolons,
+// whichever the first line has more of.
+func NewCSVReader(r io.Reader) (Reader, error) {
+	const op = "table.NewCSVReader"
+
+	br := bufio.NewReader(r)
+
+	if bom, err := br.Peek(len(utf8BOM)); err == nil && string(bom) == utf8BOM {
+		if _, err := br.Discard(len(utf8BOM)); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// The first line is only peeked at, a longer one falls back to commas.
+	firstLine, err := br.Peek(br.Size())
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if i := bytes.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	if bytes.Count(firstLine, []byte{';'}) > bytes.Count(firstLine, []byte{','}) {
+		cr.Comma = ';'
+	}
+
+	return &csvReader{r: cr}, nil
+}
+
+func (cr *csvReader) ReadRow() ([]string, error) {
+	row, err := cr.r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range row {
+		row[i] = unescapeFormula(value)
+	}
+
+	return row, nil
+}
+
+func (cr *csvReader) Close() error {
+	return nil
+}
+
+type xlsxReader struct {
+	file *excelize.File
+	rows *excelize.Rows
+}
+
+// NewXLSXReader returns a reader of the first sheet of an XLSX workbook.
+func NewXLSXReader(r io.Reader) (Reader, error) {
+	const op = "table.NewXLSXReader"
+
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		file.Close()
+		return nil, fmt.Errorf("%s: %w", op, ErrEmptyWorkbook)
+	}
+
+	rows, err := file.Rows(sheets[0])
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &xlsxReader{file: file, rows: rows}, nil
+}
+
+func (xr *xlsxReader) ReadRow() ([]string, error) {
+	if !xr.rows.Next() {
+		if err := xr.rows.Error(); err != nil {
+			return nil, err
+		}
+
+		return nil, io.EOF
+	}
+
+	return xr.rows.Columns()
+}
+
+func (xr *xlsxReader) Close() error {
+	if err := xr.rows.Close(); err != nil {
+		xr.file.Close()
+		return err
+	}
+
+	return xr.file.Close()
+}
@@ -0,0 +1,184 @@
+package table
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("unknown table format")
+
+// utf8BOM makes Excel read a CSV file as UTF-8.
+const utf8BOM = "\uFEFF"
+
+// formulaPrefixes are the first characters that make Excel read a CSV value as a formula.
+const formulaPrefixes = "=+-@\t\r"
+
+// sheetName is the name of the only sheet of an XLSX table.
+const sheetName = "Sheet1"
+
+// Writer writes a table row by row. The table is complete once Close returns.
+type Writer interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+// New returns a writer of a table in the given format with the header as the first row.
+func New(format string, w io.Writer, header []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSV(w, header)
+	case FormatXLSX:
+		return NewXLSX(w, header)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// ContentType returns the media type of the format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSV returns a writer of a UTF-8 CSV table with a byte order mark, rows are written through to w.
+//
+// Fields are separated with semicolons, as Excel expects them in the Russian locale.
+func NewCSV(w io.Writer, header []string) (Writer, error) {
+	const op = "table.NewCSV"
+
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	csvw := csv.NewWriter(w)
+	csvw.Comma = ';'
+
+	cw := &csvWriter{w: csvw}
+	if err := cw.WriteRow(header); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return cw, nil
+}
+
+// WriteRow writes the values as text: the ones Excel would run as formulas are prefixed with an apostrophe.
+// XLSX cells are always strings, so they don't need it.
+func (cw *csvWriter) WriteRow(values []string) error {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escapeFormula(value)
+	}
+
+	return cw.w.Write(escaped)
+}
+
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// unescapeFormula undoes escapeFormula, so that exported CSV tables can be imported back.
+func unescapeFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+
+	return value
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+
+	return cw.w.Error()
+}
+
+type xlsxWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+// NewXLSX returns a writer of an XLSX table with a frozen header row.
+//
+// Rows are buffered by excelize, in a temporary file once they don't fit in memory,
+// and the workbook is written to w on Close.
+func NewXLSX(w io.Writer, header []string) (Writer, error) {
+	const op = "table.NewXLSX"
+
+	file := excelize.NewFile()
+
+	stream, err := file.NewStreamWriter(sheetName)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = stream.SetPanes(&excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	xw := &xlsxWriter{w: w, file: file, stream: stream}
+	if err := xw.WriteRow(header); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return xw, nil
+}
+
+func (xw *xlsxWriter) WriteRow(values []string) error {
+	xw.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, xw.row)
+	if err != nil {
+		return err
+	}
+
+	row := make([]interface{}, len(values))
+	for i, value := range values {
+		row[i] = value
+	}
+
+	return xw.stream.SetRow(cell, row)
+}
+
+func (xw *xlsxWriter) Close() error {
+	defer xw.file.Close()
+
+	if err := xw.stream.Flush(); err != nil {
+		return err
+	}
+
+	return xw.file.Write(xw.w)
+}
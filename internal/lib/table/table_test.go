@@ -0,0 +1,59 @@
+package table
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	row := []string{"=HYPERLINK(\"http://evil\")", "+79123456789", "-1", "@SUM(A1)", "\tx", "plain", "", "a=b"}
+
+	var buf bytes.Buffer
+	w, err := NewCSV(&buf, []string{"a", "b", "c", "d", "e", "f", "g", "h"})
+	if err != nil {
+		t.Fatalf("NewCSV: %v", err)
+	}
+	if err := w.WriteRow(row); err != nil {
+		t.Fatalf("WriteRow: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimPrefix(buf.String(), utf8BOM), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("written table %q has no data row", buf.String())
+	}
+	if lines[0] != "a;b;c;d;e;f;g;h" {
+		t.Errorf("header = %q, want the fields separated with semicolons", lines[0])
+	}
+	for _, field := range []string{`"'=HYPERLINK(""http://evil"")"`, "'+79123456789", "'-1", "'@SUM(A1)", "'\tx"} {
+		if !strings.Contains(lines[1], field) {
+			t.Errorf("row %q does not contain %q", lines[1], field)
+		}
+	}
+
+	r, err := NewCSVReader(&buf)
+	if err != nil {
+		t.Fatalf("NewCSVReader: %v", err)
+	}
+
+	if _, err := r.ReadRow(); err != nil {
+		t.Fatalf("read header: %v", err)
+	}
+
+	got, err := r.ReadRow()
+	if err != nil {
+		t.Fatalf("read row: %v", err)
+	}
+	if !slices.Equal(got, row) {
+		t.Errorf("read back %q, want %q", got, row)
+	}
+
+	if _, err := r.ReadRow(); err != io.EOF {
+		t.Errorf("ReadRow after the last row error = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,219 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Default length limits of the text answers, the same as of the core text fields.
+const (
+	DefaultTextLength     = 300
+	DefaultTextareaLength = 3000
+)
+
+// AnswerError is a failed rule of an answer to an additional question.
+//
+// The rules are the validator ones where they mean the same: required, max with the length
+// and oneof with the options; number means the answer is not a number, type that it has
+// the wrong JSON type for the question and unknown that the form has no such question.
+type AnswerError struct {
+	Name  string
+	Label string
+	Rule  string
+	Param string
+}
+
+// AnswerErrors are all the failed rules of the answers.
+type AnswerErrors []AnswerError
+
+func (errs AnswerErrors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, fmt.Sprintf("answer %s failed on the %s rule", err.Name, err.Rule))
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Answers checks the answers against the questions of the form and returns them normalized:
+// text is trimmed, numbers given as strings are parsed and empty answers are left out.
+//
+// Only the active questions may be required. Answers to questions the form doesn't have
+// are rejected. The error is AnswerErrors if any answer is not valid.
+func Answers(fields []models.FormField, answers models.Answers) (models.Answers, error) {
+	var (
+		errs       AnswerErrors
+		normalized = models.Answers{}
+	)
+
+	for _, field := range fields {
+		value, err := answer(field, answers[field.Name])
+		if err != nil {
+			errs = append(errs, *err)
+			continue
+		}
+
+		if value == nil {
+			if field.Active && field.Required {
+				errs = append(errs, AnswerError{Name: field.Name, Label: field.Label, Rule: "required"})
+			}
+			continue
+		}
+
+		normalized[field.Name] = value
+	}
+
+	names := make([]string, 0, len(answers))
+	for name := range answers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		known := slices.ContainsFunc(fields, func(field models.FormField) bool { return field.Name == name })
+		if !known {
+			errs = append(errs, AnswerError{Name: name, Label: name, Rule: "unknown"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+
+	return normalized, nil
+}
+
+// answer returns the normalized answer to the question, nil if it is empty.
+func answer(field models.FormField, value any) (any, *AnswerError) {
+	fail := func(rule, param string) (any, *AnswerError) {
+		return nil, &AnswerError{Name: field.Name, Label: field.Label, Rule: rule, Param: param}
+	}
+
+	if value == nil {
+		return nil, nil
+	}
+
+	switch field.Type {
+	case models.FieldText, models.FieldTextarea:
+		s, ok := value.(string)
+		if !ok {
+			return fail("type", string(field.Type))
+		}
+
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return nil, nil
+		}
+
+		if limit := MaxAnswerLength(field); utf8.RuneCountInString(s) > limit {
+			return fail("max", strconv.Itoa(limit))
+		}
+
+		return s, nil
+
+	case models.FieldNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case json.Number:
+			n, err := v.Float64()
+			if err != nil {
+				return fail("number", "")
+			}
+			return n, nil
+		case string:
+			v = strings.TrimSpace(v)
+			if v == "" {
+				return nil, nil
+			}
+			n, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", "."), 64)
+			if err != nil {
+				return fail("number", "")
+			}
+			return n, nil
+		default:
+			return fail("number", "")
+		}
+
+	case models.FieldSelect:
+		s, ok := value.(string)
+		if !ok {
+			return fail("type", string(field.Type))
+		}
+
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return nil, nil
+		}
+
+		if !slices.Contains(field.Options, s) {
+			return fail("oneof", strings.Join(field.Options, ", "))
+		}
+
+		return s, nil
+
+	case models.FieldMultiselect:
+		items, ok := value.([]any)
+		if !ok {
+			return fail("type", string(field.Type))
+		}
+
+		var picked []string
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				return fail("type", string(field.Type))
+			}
+
+			s = strings.TrimSpace(s)
+			if !slices.Contains(field.Options, s) {
+				return fail("oneof", strings.Join(field.Options, ", "))
+			}
+			if !slices.Contains(picked, s) {
+				picked = append(picked, s)
+			}
+		}
+
+		if len(picked) == 0 {
+			return nil, nil
+		}
+
+		return picked, nil
+
+	case models.FieldCheckbox:
+		checked, ok := value.(bool)
+		if !ok {
+			return fail("type", string(field.Type))
+		}
+
+		// A required checkbox is a consent, it has to be checked.
+		if !checked && field.Active && field.Required {
+			return fail("required", "")
+		}
+
+		return checked, nil
+
+	default:
+		return fail("type", string(field.Type))
+	}
+}
+
+// MaxAnswerLength returns the length limit of the text answers to the question.
+func MaxAnswerLength(field models.FormField) int {
+	switch {
+	case field.MaxLength > 0:
+		return field.MaxLength
+	case field.Type == models.FieldTextarea:
+		return DefaultTextareaLength
+	default:
+		return DefaultTextLength
+	}
+}
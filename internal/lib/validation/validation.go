@@ -0,0 +1,91 @@
+package validation
+
+import (
+	"github.com/go-playground/validator/v10"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/phone"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Enum is a list of codes the enum rule accepts with their labels for the validation messages.
+type Enum struct {
+	Codes  []string
+	Labels []string
+}
+
+// Enums are the enums the enum rule checks against, by name: `validate:"enum=project_level"`.
+var Enums = map[string]Enum{
+	"status":           enumOf(models.Statuses),
+	"project_duration": enumOf(models.ProjectDurations),
+	"project_level":    enumOf(models.ProjectLevels),
+}
+
+func enumOf[E models.Enum](values []E) Enum {
+	enum := Enum{Codes: models.Codes(values)}
+	for _, value := range values {
+		enum.Labels = append(enum.Labels, value.Label(models.DefaultLocale))
+	}
+
+	return enum
+}
+
+// New returns a validator with the rules of this service on top of the standard ones:
+//
+// - phone accepts the numbers phone.Normalize accepts;
+//
+// - enum=<name> accepts the values of the Enums list with the given name.
+//
+// Errors name the fields after their JSON names, as the clients send them,
+// Label returns the label struct tag the messages name them after.
+func New() *validator.Validate {
+	validate := validator.New()
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := field.Name
+		if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag != "" && tag != "-" {
+			name = tag
+		}
+
+		if label := field.Tag.Get("label"); label != "" {
+			labels.Store(labelKey{field.Name, name}, label)
+		}
+
+		return name
+	})
+
+	// The rules can't fail to register: their tags are valid and the functions are not nil.
+	_ = validate.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		_, err := phone.Normalize(fl.Field().String())
+
+		return err == nil
+	})
+
+	_ = validate.RegisterValidation("enum", func(fl validator.FieldLevel) bool {
+		enum, ok := Enums[fl.Param()]
+
+		return ok && slices.Contains(enum.Codes, fl.Field().String())
+	})
+
+	return validate
+}
+
+// labels are the label struct tags of the fields validated so far, the validator looks the fields up
+// once per struct type and only keeps their names.
+var labels sync.Map
+
+type labelKey struct {
+	structField string
+	name        string
+}
+
+// Label returns the label of the field that failed validation, its name if it has none.
+func Label(err validator.FieldError) string {
+	if label, ok := labels.Load(labelKey{err.StructField(), err.Field()}); ok {
+		return label.(string)
+	}
+
+	return err.Field()
+}
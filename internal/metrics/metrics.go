@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/logger/sl"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "showcase"
+
+// Metrics are the Prometheus metrics of the service, kept in a registry of their own.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	storageDuration *prometheus.HistogramVec
+	submitted       *prometheus.CounterVec
+}
+
+// Sources of the submitted applications.
+const (
+	sourceForm   = "form"
+	sourceImport = "import"
+)
+
+// New creates the metrics and registers them together with the Go runtime and process metrics.
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route pattern and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Time taken to serve HTTP requests by method, route pattern and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		storageDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "operation_duration_seconds",
+			Help:      "Time taken by storage operations.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"op"}),
+		submitted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "applications",
+			Name:      "submitted_total",
+			Help:      "Applications submitted with the form or imported, by source.",
+		}, []string{"source"}),
+	}
+
+	// Both sources are reported from the start, so that increase() sees the first submissions.
+	m.submitted.WithLabelValues(sourceForm)
+	m.submitted.WithLabelValues(sourceImport)
+
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.storageDuration,
+		m.submitted,
+	)
+
+	return m
+}
+
+// ObserveRequest records a served HTTP request. route is the pattern the request matched, not its path,
+// so that the number of series stays bounded.
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+
+	m.httpRequests.WithLabelValues(method, route, statusLabel).Inc()
+	m.httpDuration.WithLabelValues(method, route, statusLabel).Observe(duration.Seconds())
+}
+
+// ObserveOp records a storage operation, it makes Metrics a storage.OpObserver.
+func (m *Metrics) ObserveOp(op string, duration time.Duration) {
+	m.storageDuration.WithLabelValues(op).Observe(duration.Seconds())
+}
+
+// ObserveSubmitted records an application submitted with the form.
+func (m *Metrics) ObserveSubmitted() {
+	m.submitted.WithLabelValues(sourceForm).Inc()
+}
+
+// ObserveImported records n imported applications.
+func (m *Metrics) ObserveImported(n int) {
+	m.submitted.WithLabelValues(sourceImport).Add(float64(n))
+}
+
+type ApplicationCounter interface {
+	CountApplicationsByStatus(ctx context.Context) (map[models.Status]int, error)
+}
+
+// RegisterApplications adds the gauge of applications by status.
+// It is counted in the storage on every scrape, so it is right whichever instance changed the statuses.
+func (m *Metrics) RegisterApplications(log *slog.Logger, counter ApplicationCounter) {
+	m.Registry.MustRegister(&applicationsCollector{
+		log:     log.With(slog.String("component", "metrics")),
+		counter: counter,
+		byStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "applications", "by_status"),
+			"Applications by status, not counting the trash.",
+			[]string{"status"}, nil,
+		),
+	})
+}
+
+type applicationsCollector struct {
+	log      *slog.Logger
+	counter  ApplicationCounter
+	byStatus *prometheus.Desc
+}
+
+func (c *applicationsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.byStatus
+}
+
+// Collect leaves out the gauge if it fails to count, the scrape still returns the other metrics.
+func (c *applicationsCollector) Collect(ch chan<- prometheus.Metric) {
+	// Prometheus doesn't pass the context of the scrape to collectors.
+	ctx := context.Background()
+
+	counts, err := c.counter.CountApplicationsByStatus(ctx)
+	if err != nil {
+		c.log.Error("failed to count applications by status", sl.Err(err))
+		return
+	}
+
+	// Every status is reported, with no applications too, so that the series don't disappear.
+	for _, status := range models.Statuses {
+		ch <- prometheus.MustNewConstMetric(c.byStatus, prometheus.GaugeValue, float64(counts[status]), string(status))
+	}
+}
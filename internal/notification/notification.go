@@ -0,0 +1,190 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/logger/sl"
+	"projectsShowcase/internal/lib/mail"
+	"projectsShowcase/internal/storage"
+	"time"
+)
+
+const (
+	// batchSize is the maximum number of notifications sent per poll.
+	batchSize = 50
+
+	// Delays between the attempts to send a notification grow exponentially within these bounds.
+	minRetryDelay = time.Minute
+	maxRetryDelay = 6 * time.Hour
+)
+
+type Repository interface {
+	GetPendingNotifications(ctx context.Context, now time.Time, limit int) ([]models.Notification, error)
+	MarkNotificationSent(ctx context.Context, id int64) error
+	RetryNotification(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+	FailNotification(ctx context.Context, id int64, lastError string) error
+	GetApplicationByID(ctx context.Context, id int64) (*models.Application, error)
+}
+
+type Sender interface {
+	Send(msg mail.Message) error
+}
+
+type LinkBuilder interface {
+	SelfServiceLink(publicID string) string
+}
+
+// Dispatcher sends the notifications queued in the outbox.
+//
+// Notifications are queued by the storage in the same transaction as the change they are about
+// and sent in the background, so a mail server outage only delays them.
+type Dispatcher struct {
+	log          *slog.Logger
+	repo         Repository
+	sender       Sender
+	templates    *Templates
+	links        LinkBuilder
+	pollInterval time.Duration
+	maxAttempts  int
+}
+
+func New(
+	log *slog.Logger,
+	repo Repository,
+	sender Sender,
+	templates *Templates,
+	links LinkBuilder,
+	pollInterval time.Duration,
+	maxAttempts int,
+) *Dispatcher {
+	return &Dispatcher{
+		log:          log.With(slog.String("component", "notification")),
+		repo:         repo,
+		sender:       sender,
+		templates:    templates,
+		links:        links,
+		pollInterval: pollInterval,
+		maxAttempts:  maxAttempts,
+	}
+}
+
+// Run polls the outbox until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	d.log.Info("notification dispatcher started", slog.String("poll_interval", d.pollInterval.String()))
+
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			d.log.Info("notification dispatcher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch sends the notifications that are due.
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	notifications, err := d.repo.GetPendingNotifications(ctx, time.Now(), batchSize)
+	if err != nil {
+		d.log.Error("failed to get pending notifications", sl.Err(err))
+		return
+	}
+
+	for _, notification := range notifications {
+		if ctx.Err() != nil {
+			return
+		}
+
+		d.send(ctx, notification)
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, notification models.Notification) {
+	log := d.log.With(
+		slog.Int64("notification_id", notification.ID),
+		slog.Int64("application_id", notification.ApplicationID),
+		slog.String("kind", notification.Kind),
+	)
+
+	err := d.deliver(ctx, notification)
+	if err == nil {
+		if err := d.repo.MarkNotificationSent(ctx, notification.ID); err != nil {
+			log.Error("failed to mark notification as sent", sl.Err(err))
+			return
+		}
+
+		log.Info("notification sent")
+
+		return
+	}
+
+	attempts := notification.Attempts + 1
+
+	if attempts >= d.maxAttempts || permanent(err) {
+		log.Error("failed to send notification, giving up", sl.Err(err), slog.Int("attempts", attempts))
+
+		if err := d.repo.FailNotification(ctx, notification.ID, err.Error()); err != nil {
+			log.Error("failed to mark notification as failed", sl.Err(err))
+		}
+
+		return
+	}
+
+	nextAttemptAt := time.Now().Add(retryDelay(attempts))
+
+	log.Warn("failed to send notification, will retry",
+		sl.Err(err),
+		slog.Int("attempts", attempts),
+		slog.Time("next_attempt_at", nextAttemptAt),
+	)
+
+	if err := d.repo.RetryNotification(ctx, notification.ID, err.Error(), nextAttemptAt); err != nil {
+		log.Error("failed to reschedule notification", sl.Err(err))
+	}
+}
+
+// deliver renders the notification for the current state of the application and sends it.
+func (d *Dispatcher) deliver(ctx context.Context, notification models.Notification) error {
+	application, err := d.repo.GetApplicationByID(ctx, notification.ApplicationID)
+	if err != nil {
+		return err
+	}
+
+	subject, body, err := d.templates.Render(notification.Kind, TemplateData{
+		Application:     *application,
+		SelfServiceLink: d.links.SelfServiceLink(application.PublicID),
+	})
+	if err != nil {
+		return err
+	}
+
+	return d.sender.Send(mail.Message{
+		To:      notification.Recipient,
+		Subject: subject,
+		Body:    body,
+	})
+}
+
+// permanent reports whether retrying to send the notification won't help.
+func permanent(err error) bool {
+	return errors.Is(err, storage.ErrApplicationNotFound) ||
+		errors.Is(err, ErrUnknownKind) ||
+		errors.Is(err, mail.ErrInvalidAddress)
+}
+
+// retryDelay returns how long to wait before the next attempt after the given number of failed ones.
+func retryDelay(attempts int) time.Duration {
+	delay := minRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
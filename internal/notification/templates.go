@@ -0,0 +1,99 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"projectsShowcase/internal/domain/models"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+var ErrUnknownKind = errors.New("unknown notification kind")
+
+// DefaultLocale is the locale of the messages unless configured otherwise.
+const DefaultLocale = "ru"
+
+// TemplateData is what the message templates are executed with.
+type TemplateData struct {
+	Application models.Application
+	// SelfServiceLink lets the applicant view and edit the application, it may be empty.
+	SelfServiceLink string
+}
+
+// Templates renders the messages of one locale.
+//
+// Every notification kind has a templates/<locale>/<kind>.tmpl file
+// defining a "subject" and a "body" template.
+type Templates struct {
+	byKind map[string]*template.Template
+}
+
+// LoadTemplates parses the embedded templates of the locale.
+func LoadTemplates(locale string) (*Templates, error) {
+	const op = "notification.LoadTemplates"
+
+	dir := path.Join("templates", locale)
+
+	entries, err := fs.ReadDir(templatesFS, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: locale %q: %w", op, locale, err)
+	}
+
+	byKind := make(map[string]*template.Template)
+	for _, entry := range entries {
+		kind, ok := strings.CutSuffix(entry.Name(), ".tmpl")
+		if !ok {
+			continue
+		}
+
+		t, err := template.ParseFS(templatesFS, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, name := range []string{"subject", "body"} {
+			if t.Lookup(name) == nil {
+				return nil, fmt.Errorf("%s: %s has no %q template", op, entry.Name(), name)
+			}
+		}
+
+		byKind[kind] = t
+	}
+
+	for _, kind := range []string{models.NotificationReceived, models.NotificationApproved, models.NotificationRejected} {
+		if _, ok := byKind[kind]; !ok {
+			return nil, fmt.Errorf("%s: locale %q has no %s template", op, locale, kind)
+		}
+	}
+
+	return &Templates{byKind: byKind}, nil
+}
+
+// Render returns the subject and the body of the notification of the given kind.
+func (t *Templates) Render(kind string, data TemplateData) (string, string, error) {
+	const op = "notification.Templates.Render"
+
+	tmpl, ok := t.byKind[kind]
+	if !ok {
+		return "", "", fmt.Errorf("%s: %q: %w", op, kind, ErrUnknownKind)
+	}
+
+	var subject, body bytes.Buffer
+
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return strings.TrimSpace(subject.String()), body.String(), nil
+}
@@ -0,0 +1,432 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrSchemaTooNew      = errors.New("database schema is newer than the application")
+	ErrPendingMigrations = errors.New("database has pending migrations")
+	ErrNoDownMigration   = errors.New("migration has no down script")
+	ErrNothingToRollback = errors.New("no applied migrations to roll back")
+	// ErrForeignKeyViolation means a migration left rows referencing rows that don't exist.
+	ErrForeignKeyViolation = errors.New("migration violates foreign keys")
+)
+
+// Dialect describes the differences between SQL databases the migrator has to care about.
+type Dialect int
+
+const (
+	DialectSQLite Dialect = iota
+	DialectPostgres
+)
+
+// placeholder returns the bind parameter for the n-th (1-based) argument of a query.
+func (d Dialect) placeholder(n int) string {
+	if d == DialectPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+
+	return "?"
+}
+
+// Migration is a single versioned schema change.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes whether a known migration has been applied to the database.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+	afterApply map[int64]func() error
+}
+
+var fileNameRe = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// New creates a migrator for db with the migrations found in the root of fsys.
+//
+// Migration files are named <version>_<name>.up.sql and <version>_<name>.down.sql,
+// the down script is optional. Migrations are applied in ascending version order.
+func New(db *sql.DB, dialect Dialect, fsys fs.FS) (*Migrator, error) {
+	const op = "storage.migrate.New"
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("%s: read migrations: %w", op, err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileNameRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: parse version of %s: %w", op, entry.Name(), err)
+		}
+
+		script, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("%s: read %s: %w", op, entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("%s: migration %d has conflicting names %q and %q", op, version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(script)
+		} else {
+			m.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("%s: migration %d_%s has no up script", op, m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// Latest returns the version of the newest migration known to the application.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the version of the newest migration applied to the database.
+func (m *Migrator) Version() (int64, error) {
+	const op = "storage.migrate.Version"
+
+	if err := m.ensureTable(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var version sql.NullInt64
+
+	err := m.db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version.Int64, nil
+}
+
+// Check verifies that the database schema matches the application.
+//
+// It returns ErrSchemaTooNew if the database has migrations the application doesn't know about
+// and ErrPendingMigrations if some of the known migrations are not applied yet.
+func (m *Migrator) Check() error {
+	const op = "storage.migrate.Check"
+
+	version, err := m.Version()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if version > m.Latest() {
+		return fmt.Errorf("%s: database version %d, application version %d: %w", op, version, m.Latest(), ErrSchemaTooNew)
+	}
+
+	pending, err := m.pending()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("%s: %d migration(s) not applied: %w", op, len(pending), ErrPendingMigrations)
+	}
+
+	return nil
+}
+
+// AfterApply registers fn to run when Up has applied the migration with the given version,
+// once all pending migrations are applied. It fills in the data SQL alone can't, e.g. computed in Go.
+//
+// An error of fn fails Up, the migration stays applied.
+func (m *Migrator) AfterApply(version int64, fn func() error) {
+	if m.afterApply == nil {
+		m.afterApply = map[int64]func() error{}
+	}
+
+	m.afterApply[version] = fn
+}
+
+// Up applies all pending migrations and returns the number of applied ones.
+func (m *Migrator) Up() (int, error) {
+	const op = "storage.migrate.Up"
+
+	version, err := m.Version()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if version > m.Latest() {
+		return 0, fmt.Errorf("%s: database version %d, application version %d: %w", op, version, m.Latest(), ErrSchemaTooNew)
+	}
+
+	pending, err := m.pending()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i, migration := range pending {
+		if err := m.apply(migration); err != nil {
+			return i, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	for _, migration := range pending {
+		fn, ok := m.afterApply[migration.Version]
+		if !ok {
+			continue
+		}
+
+		if err := fn(); err != nil {
+			return len(pending), fmt.Errorf("%s: after %d_%s: %w", op, migration.Version, migration.Name, err)
+		}
+	}
+
+	return len(pending), nil
+}
+
+// Down rolls back the newest applied migration and returns it.
+func (m *Migrator) Down() (Migration, error) {
+	const op = "storage.migrate.Down"
+
+	version, err := m.Version()
+	if err != nil {
+		return Migration{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if version == 0 {
+		return Migration{}, fmt.Errorf("%s: %w", op, ErrNothingToRollback)
+	}
+
+	var migration *Migration
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			migration = &m.migrations[i]
+			break
+		}
+	}
+
+	if migration == nil {
+		return Migration{}, fmt.Errorf("%s: database version %d: %w", op, version, ErrSchemaTooNew)
+	}
+
+	if migration.Down == "" {
+		return Migration{}, fmt.Errorf("%s: %d_%s: %w", op, migration.Version, migration.Name, ErrNoDownMigration)
+	}
+
+	if err := m.rollback(*migration); err != nil {
+		return Migration{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return *migration, nil
+}
+
+// Status returns the state of every known migration.
+func (m *Migrator) Status() ([]Status, error) {
+	const op = "storage.migrate.Status"
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses = append(statuses, Status{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+	}
+
+	return statuses, nil
+}
+
+func (m *Migrator) ensureTable() error {
+	_, err := m.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL)`)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) applied() (map[int64]time.Time, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.Query(`SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			version   int64
+			appliedAt time.Time
+		)
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("scan schema_migrations: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate schema_migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) pending() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+func (m *Migrator) apply(migration Migration) error {
+	return m.transaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(migration.Up); err != nil {
+			return fmt.Errorf("apply %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		query := fmt.Sprintf(`INSERT INTO schema_migrations(version, name, applied_at) VALUES(%s, %s, %s)`,
+			m.dialect.placeholder(1), m.dialect.placeholder(2), m.dialect.placeholder(3))
+		if _, err := tx.Exec(query, migration.Version, migration.Name, time.Now().UTC()); err != nil {
+			return fmt.Errorf("record %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		return nil
+	})
+}
+
+func (m *Migrator) rollback(migration Migration) error {
+	return m.transaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(migration.Down); err != nil {
+			return fmt.Errorf("roll back %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		query := fmt.Sprintf(`DELETE FROM schema_migrations WHERE version = %s`, m.dialect.placeholder(1))
+		if _, err := tx.Exec(query, migration.Version); err != nil {
+			return fmt.Errorf("unrecord %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		return nil
+	})
+}
+
+// transaction runs fn in a transaction and commits it if fn succeeds.
+//
+// On SQLite foreign keys are not enforced inside the transaction, so that a migration can rebuild a table
+// other tables reference: with them on, dropping the old table would delete the referencing rows.
+// The foreign keys are checked before the commit instead.
+func (m *Migrator) transaction(fn func(tx *sql.Tx) error) (err error) {
+	ctx := context.Background()
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if m.dialect == DialectSQLite {
+		// The pragma is a no-op inside a transaction.
+		if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+			return fmt.Errorf("disable foreign keys: %w", err)
+		}
+		defer func() {
+			if _, restoreErr := conn.ExecContext(ctx, `PRAGMA foreign_keys = ON`); restoreErr != nil && err == nil {
+				err = fmt.Errorf("enable foreign keys: %w", restoreErr)
+			}
+		}()
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if m.dialect == DialectSQLite {
+		var table string
+		err := tx.QueryRow(`PRAGMA foreign_key_check`).Scan(&table)
+		if err == nil {
+			return fmt.Errorf("%w: table %s", ErrForeignKeyViolation, table)
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("check foreign keys: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/storage"
+	"time"
+)
+
+// insertAttachments links the attachments, already put into the blob store, to the application in the transaction.
+// It fills in the IDs and creation times of the attachments.
+func insertAttachments(ctx context.Context, tx *sql.Tx, applicationID int64, attachments []models.Attachment) error {
+	for i := range attachments {
+		attachment := &attachments[i]
+		attachment.ApplicationID = applicationID
+
+		err := tx.QueryRowContext(ctx, `INSERT INTO attachments(application_id, blob_key, file_name, content_type, size)
+			VALUES($1, $2, $3, $4, $5)
+			RETURNING id, created_at`,
+			applicationID, attachment.BlobKey, attachment.FileName, attachment.ContentType, attachment.Size,
+		).Scan(&attachment.ID, &attachment.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("insert attachment %s: %w", attachment.FileName, err)
+		}
+	}
+
+	return nil
+}
+
+// GetApplicationAttachments returns the attachments of the application in the order they were uploaded.
+func (s *Storage) GetApplicationAttachments(ctx context.Context, applicationID int64) (_ []models.Attachment, err error) {
+	const op = "storage.postgres.GetApplicationAttachments"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+attachmentColumns+`
+		FROM attachments WHERE application_id = $1 ORDER BY id`, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	attachments := []models.Attachment{}
+
+	for rows.Next() {
+		var attachment models.Attachment
+		if err := scanAttachment(rows, &attachment); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	return attachments, nil
+}
+
+// MarkBlobsOrphaned records that the blobs may no longer be used by any attachment, see ClaimOrphanedBlob.
+// Marking a blob again restarts its grace period.
+func (s *Storage) MarkBlobsOrphaned(ctx context.Context, blobKeys []string) (err error) {
+	const op = "storage.postgres.MarkBlobsOrphaned"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	for _, key := range blobKeys {
+		_, err = s.db.ExecContext(ctx, `INSERT INTO orphan_blobs(blob_key) VALUES($1)
+			ON CONFLICT(blob_key) DO UPDATE SET marked_at = CURRENT_TIMESTAMP`, key)
+		if err != nil {
+			return fmt.Errorf("%s: execute statement: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// GetOrphanedBlobs returns the keys of the blobs marked as orphaned before the given time.
+func (s *Storage) GetOrphanedBlobs(ctx context.Context, markedBefore time.Time) (_ []string, err error) {
+	const op = "storage.postgres.GetOrphanedBlobs"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT blob_key FROM orphan_blobs WHERE marked_at < $1 ORDER BY marked_at`,
+		markedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	var blobKeys []string
+
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		blobKeys = append(blobKeys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	return blobKeys, nil
+}
+
+// ClaimOrphanedBlob removes the orphan mark of the blob and reports whether the blob can be deleted:
+// it was marked before the given time and no attachment has it. A blob that is in use again
+// is unmarked without being claimed, one marked later keeps its mark.
+func (s *Storage) ClaimOrphanedBlob(ctx context.Context, blobKey string, markedBefore time.Time) (_ bool, err error) {
+	const op = "storage.postgres.ClaimOrphanedBlob"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM orphan_blobs WHERE blob_key = $1
+		AND EXISTS(SELECT 1 FROM attachments WHERE blob_key = $1)`, blobKey)
+	if err != nil {
+		return false, fmt.Errorf("%s: unmark used blob: %w", op, err)
+	}
+
+	inUse, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to get rows affected: %w", op, err)
+	}
+
+	var claimed int64
+
+	if inUse == 0 {
+		res, err := tx.ExecContext(ctx, `DELETE FROM orphan_blobs WHERE blob_key = $1 AND marked_at < $2`,
+			blobKey, markedBefore)
+		if err != nil {
+			return false, fmt.Errorf("%s: claim blob: %w", op, err)
+		}
+
+		if claimed, err = res.RowsAffected(); err != nil {
+			return false, fmt.Errorf("%s: failed to get rows affected: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("%s: commit transaction: %w", op, err)
+	}
+
+	return claimed > 0, nil
+}
+
+// GetAttachment returns the attachment of the application.
+//
+// It returns storage.ErrAttachmentNotFound if the application has no such attachment.
+func (s *Storage) GetAttachment(ctx context.Context, applicationID, attachmentID int64) (_ *models.Attachment, err error) {
+	const op = "storage.postgres.GetAttachment"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	var attachment models.Attachment
+
+	err = scanAttachment(s.db.QueryRowContext(ctx, `SELECT `+attachmentColumns+`
+		FROM attachments WHERE application_id = $1 AND id = $2`, applicationID, attachmentID), &attachment)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &attachment, nil
+}
+
+const attachmentColumns = `id, application_id, blob_key, file_name, content_type, size, created_at`
+
+func scanAttachment(row rowScanner, attachment *models.Attachment) error {
+	return row.Scan(
+		&attachment.ID,
+		&attachment.ApplicationID,
+		&attachment.BlobKey,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.CreatedAt,
+	)
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"errors"
+	"projectsShowcase/internal/storage"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE codes of constraint violations.
+const (
+	uniqueViolation = "23505"
+	checkViolation  = "23514"
+)
+
+// constraintError translates a CHECK constraint violation into the matching storage error.
+//
+// It returns nil if err is not a violation of one of the known constraints.
+func constraintError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != checkViolation {
+		return nil
+	}
+
+	switch pgErr.ConstraintName {
+	case "applications_project_duration_check":
+		return storage.ErrProjectDuration
+	case "applications_project_level_check":
+		return storage.ErrProjectLevel
+	case "applications_status_check":
+		return storage.ErrProjectStatus
+	case "admin_users_role_check":
+		return storage.ErrAdminRole
+	}
+
+	return nil
+}
+
+// isUniqueViolation reports whether err is a violation of a UNIQUE or PRIMARY KEY constraint.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/storage"
+)
+
+const formFieldColumns = `id, name, label, type, required, options, max_length, position, active, created_at, updated_at`
+
+// GetFormFields returns the additional questions of the form, the inactive ones included, in the order of the form.
+func (s *Storage) GetFormFields(ctx context.Context) (_ []models.FormField, err error) {
+	const op = "storage.postgres.GetFormFields"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+formFieldColumns+` FROM form_fields ORDER BY position, id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	fields := []models.FormField{}
+	for rows.Next() {
+		var field models.FormField
+		if err := scanFormField(rows, &field); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		fields = append(fields, field)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	return fields, nil
+}
+
+// GetFormField returns the question with the given ID.
+//
+// It returns storage.ErrFormFieldNotFound if there is no such question.
+func (s *Storage) GetFormField(ctx context.Context, id int64) (_ *models.FormField, err error) {
+	const op = "storage.postgres.GetFormField"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	var field models.FormField
+
+	err = scanFormField(s.db.QueryRowContext(ctx, `SELECT `+formFieldColumns+` FROM form_fields WHERE id = $1`, id), &field)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrFormFieldNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &field, nil
+}
+
+// SaveFormField adds a question to the form and returns its ID.
+//
+// It returns storage.ErrFormFieldExists if there already is a question with the name.
+func (s *Storage) SaveFormField(ctx context.Context, field models.FormField) (_ int64, err error) {
+	const op = "storage.postgres.SaveFormField"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	options, err := json.Marshal(field.Options)
+	if err != nil {
+		return 0, fmt.Errorf("%s: marshal options: %w", op, err)
+	}
+
+	var id int64
+
+	err = s.db.QueryRowContext(ctx, `INSERT INTO form_fields(name, label, type, required, options, max_length, position, active)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		field.Name, field.Label, field.Type, field.Required, string(options), field.MaxLength, field.Position, field.Active).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrFormFieldExists)
+		}
+
+		return 0, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// UpdateFormField replaces the label, options and flags of the question with the ID of the field.
+// The name and the type are kept.
+//
+// It returns storage.ErrFormFieldNotFound if there is no such question.
+func (s *Storage) UpdateFormField(ctx context.Context, field models.FormField) (err error) {
+	const op = "storage.postgres.UpdateFormField"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	options, err := json.Marshal(field.Options)
+	if err != nil {
+		return fmt.Errorf("%s: marshal options: %w", op, err)
+	}
+
+	res, err := s.db.ExecContext(ctx, `UPDATE form_fields SET
+		label = $1,
+		required = $2,
+		options = $3,
+		max_length = $4,
+		position = $5,
+		active = $6,
+		updated_at = CURRENT_TIMESTAMP
+		WHERE id = $7`,
+		field.Label, field.Required, string(options), field.MaxLength, field.Position, field.Active, field.ID)
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get rows affected: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return storage.ErrFormFieldNotFound
+	}
+
+	return nil
+}
+
+func scanFormField(row rowScanner, field *models.FormField) error {
+	var options []byte
+
+	err := row.Scan(
+		&field.ID,
+		&field.Name,
+		&field.Label,
+		&field.Type,
+		&field.Required,
+		&options,
+		&field.MaxLength,
+		&field.Position,
+		&field.Active,
+		&field.CreatedAt,
+		&field.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(options, &field.Options); err != nil {
+		return fmt.Errorf("unmarshal options: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,8 @@
+DROP TABLE application_status_history;
+
+UPDATE applications SET status = 'На рассмотрении' WHERE status IN ('На доработке', 'Отклонена');
+
+ALTER TABLE applications DROP CONSTRAINT applications_status_check;
+
+ALTER TABLE applications ADD CONSTRAINT applications_status_check
+    CHECK (status IN ('На рассмотрении', 'Допущена', 'Удалена'));
@@ -0,0 +1,16 @@
+ALTER TABLE applications DROP CONSTRAINT applications_status_check;
+
+ALTER TABLE applications ADD CONSTRAINT applications_status_check
+    CHECK (status IN ('На рассмотрении', 'На доработке', 'Допущена', 'Отклонена', 'Удалена'));
+
+CREATE TABLE application_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    changed_by TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_application_status_history_application_id ON application_status_history(application_id);
@@ -0,0 +1,3 @@
+DROP INDEX idx_applications_search_vector;
+
+ALTER TABLE applications DROP COLUMN search_vector;
@@ -0,0 +1,9 @@
+ALTER TABLE applications ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(project_name, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(keywords, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(project_goal, '')), 'B') ||
+    setweight(to_tsvector('russian', coalesce(barrier, '')), 'C') ||
+    setweight(to_tsvector('russian', coalesce(existing_solutions, '')), 'C')
+) STORED;
+
+CREATE INDEX idx_applications_search_vector ON applications USING GIN (search_vector);
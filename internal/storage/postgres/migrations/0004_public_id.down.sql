@@ -0,0 +1,3 @@
+DROP INDEX idx_applications_public_id;
+
+ALTER TABLE applications DROP COLUMN public_id;
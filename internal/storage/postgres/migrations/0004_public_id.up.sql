@@ -0,0 +1,5 @@
+ALTER TABLE applications ADD COLUMN public_id TEXT NOT NULL DEFAULT gen_random_uuid()::text;
+
+ALTER TABLE applications ALTER COLUMN public_id DROP DEFAULT;
+
+CREATE UNIQUE INDEX idx_applications_public_id ON applications(public_id);
@@ -0,0 +1,17 @@
+CREATE TABLE admin_users (
+    id BIGSERIAL PRIMARY KEY,
+    login TEXT NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL,
+    role TEXT NOT NULL CONSTRAINT admin_users_role_check CHECK (role IN ('viewer', 'reviewer', 'superadmin')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE admin_sessions (
+    token_hash TEXT PRIMARY KEY,
+    admin_user_id BIGINT NOT NULL REFERENCES admin_users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_admin_sessions_admin_user_id ON admin_sessions(admin_user_id);
+CREATE INDEX idx_admin_sessions_expires_at ON admin_sessions(expires_at);
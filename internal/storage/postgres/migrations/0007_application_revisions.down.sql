@@ -0,0 +1,3 @@
+DROP TABLE application_revisions;
+
+ALTER TABLE applications DROP COLUMN revision;
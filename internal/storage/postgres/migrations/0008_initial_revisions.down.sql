@@ -0,0 +1,5 @@
+DROP TRIGGER application_revisions_immutable ON application_revisions;
+
+DROP FUNCTION application_revisions_immutable();
+
+DELETE FROM application_revisions WHERE revision = 0;
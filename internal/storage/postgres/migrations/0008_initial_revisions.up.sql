@@ -0,0 +1,40 @@
+-- Revision 0 is the application as submitted. For applications edited before this migration
+-- it is what the first edit replaced, otherwise the current fields.
+INSERT INTO application_revisions(application_id, revision, author_type, edited_by, before, after, created_at)
+SELECT
+    a.id,
+    0,
+    'applicant',
+    a.applicant_email,
+    '{}',
+    COALESCE(
+        (SELECT r.before FROM application_revisions r WHERE r.application_id = a.id AND r.revision = 1),
+        jsonb_build_object(
+            'ApplicantName', a.applicant_name,
+            'ApplicantEmail', a.applicant_email,
+            'ApplicantPhone', a.applicant_phone,
+            'PositionAndOrganization', a.position_and_organization,
+            'ProjectDuration', a.project_duration,
+            'ProjectLevel', a.project_level,
+            'ProblemHolder', a.problem_holder,
+            'ProjectGoal', a.project_goal,
+            'Barrier', a.barrier,
+            'ExistingSolutions', a.existing_solutions,
+            'Keywords', a.keywords,
+            'InterestedParties', a.interested_parties,
+            'Consultants', a.consultants,
+            'AdditionalMaterials', a.additional_materials,
+            'ProjectName', a.project_name
+        )
+    ),
+    a.submission_date
+FROM applications a;
+
+CREATE FUNCTION application_revisions_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'application revisions are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER application_revisions_immutable BEFORE UPDATE ON application_revisions
+    FOR EACH ROW EXECUTE FUNCTION application_revisions_immutable();
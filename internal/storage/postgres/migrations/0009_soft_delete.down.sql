@@ -0,0 +1,5 @@
+UPDATE applications SET status = 'Удалена' WHERE deleted_at IS NOT NULL;
+
+DROP INDEX idx_applications_deleted_at;
+ALTER TABLE applications DROP COLUMN deleted_by;
+ALTER TABLE applications DROP COLUMN deleted_at;
@@ -0,0 +1,4 @@
+-- Fails while attachments share a blob.
+DROP INDEX idx_attachments_blob_key;
+
+ALTER TABLE attachments ADD CONSTRAINT attachments_blob_key_key UNIQUE (blob_key);
@@ -0,0 +1,4 @@
+-- Attachment content is stored under its SHA-256, attachments with the same content share a blob.
+ALTER TABLE attachments DROP CONSTRAINT attachments_blob_key_key;
+
+CREATE INDEX idx_attachments_blob_key ON attachments(blob_key);
@@ -0,0 +1,70 @@
+ALTER TABLE applications DROP CONSTRAINT applications_status_check;
+ALTER TABLE applications DROP CONSTRAINT applications_project_duration_check;
+ALTER TABLE applications DROP CONSTRAINT applications_project_level_check;
+
+UPDATE applications SET
+    project_duration = CASE project_duration
+        WHEN 'one_semester' THEN '1 семестр'
+        WHEN 'two_semesters' THEN '2 семестра'
+        ELSE project_duration END,
+    project_level = CASE project_level
+        WHEN 'diagnostic' THEN 'Диагностический проект'
+        WHEN 'educational' THEN 'Учебный проект'
+        WHEN 'educational_applied' THEN 'Учебно-прикладной проект'
+        WHEN 'applied' THEN 'Прикладной проект'
+        ELSE project_level END,
+    status = CASE status
+        WHEN 'pending' THEN 'На рассмотрении'
+        WHEN 'revision' THEN 'На доработке'
+        WHEN 'approved' THEN 'Допущена'
+        WHEN 'rejected' THEN 'Отклонена'
+        ELSE status END;
+
+ALTER TABLE applications ADD CONSTRAINT applications_status_check
+    CHECK (status IN ('На рассмотрении', 'На доработке', 'Допущена', 'Отклонена', 'Удалена'));
+ALTER TABLE applications ADD CONSTRAINT applications_project_duration_check
+    CHECK (project_duration IN ('1 семестр', '2 семестра'));
+ALTER TABLE applications ADD CONSTRAINT applications_project_level_check
+    CHECK (project_level IN ('Диагностический проект', 'Учебный проект', 'Учебно-прикладной проект', 'Прикладной проект'));
+
+UPDATE application_status_history SET
+    from_status = CASE from_status
+        WHEN 'pending' THEN 'На рассмотрении'
+        WHEN 'revision' THEN 'На доработке'
+        WHEN 'approved' THEN 'Допущена'
+        WHEN 'rejected' THEN 'Отклонена'
+        ELSE from_status END,
+    to_status = CASE to_status
+        WHEN 'pending' THEN 'На рассмотрении'
+        WHEN 'revision' THEN 'На доработке'
+        WHEN 'approved' THEN 'Допущена'
+        WHEN 'rejected' THEN 'Отклонена'
+        ELSE to_status END;
+
+ALTER TABLE application_revisions DISABLE TRIGGER application_revisions_immutable;
+
+UPDATE application_revisions SET
+    before = CASE WHEN before->>'ProjectDuration' IS NULL THEN before ELSE jsonb_set(jsonb_set(before,
+        '{ProjectDuration}', to_jsonb(CASE before->>'ProjectDuration'
+            WHEN 'one_semester' THEN '1 семестр'
+            WHEN 'two_semesters' THEN '2 семестра'
+            ELSE before->>'ProjectDuration' END)),
+        '{ProjectLevel}', to_jsonb(CASE before->>'ProjectLevel'
+            WHEN 'diagnostic' THEN 'Диагностический проект'
+            WHEN 'educational' THEN 'Учебный проект'
+            WHEN 'educational_applied' THEN 'Учебно-прикладной проект'
+            WHEN 'applied' THEN 'Прикладной проект'
+            ELSE before->>'ProjectLevel' END)) END,
+    after = CASE WHEN after->>'ProjectDuration' IS NULL THEN after ELSE jsonb_set(jsonb_set(after,
+        '{ProjectDuration}', to_jsonb(CASE after->>'ProjectDuration'
+            WHEN 'one_semester' THEN '1 семестр'
+            WHEN 'two_semesters' THEN '2 семестра'
+            ELSE after->>'ProjectDuration' END)),
+        '{ProjectLevel}', to_jsonb(CASE after->>'ProjectLevel'
+            WHEN 'diagnostic' THEN 'Диагностический проект'
+            WHEN 'educational' THEN 'Учебный проект'
+            WHEN 'educational_applied' THEN 'Учебно-прикладной проект'
+            WHEN 'applied' THEN 'Прикладной проект'
+            ELSE after->>'ProjectLevel' END)) END;
+
+ALTER TABLE application_revisions ENABLE TRIGGER application_revisions_immutable;
@@ -0,0 +1,72 @@
+-- Enum values are stored as language-neutral codes, the labels are in the application.
+
+ALTER TABLE applications DROP CONSTRAINT applications_status_check;
+ALTER TABLE applications DROP CONSTRAINT applications_project_duration_check;
+ALTER TABLE applications DROP CONSTRAINT applications_project_level_check;
+
+UPDATE applications SET
+    project_duration = CASE project_duration
+        WHEN '1 семестр' THEN 'one_semester'
+        WHEN '2 семестра' THEN 'two_semesters'
+        ELSE project_duration END,
+    project_level = CASE project_level
+        WHEN 'Диагностический проект' THEN 'diagnostic'
+        WHEN 'Учебный проект' THEN 'educational'
+        WHEN 'Учебно-прикладной проект' THEN 'educational_applied'
+        WHEN 'Прикладной проект' THEN 'applied'
+        ELSE project_level END,
+    status = CASE status
+        WHEN 'На рассмотрении' THEN 'pending'
+        WHEN 'На доработке' THEN 'revision'
+        WHEN 'Допущена' THEN 'approved'
+        WHEN 'Отклонена' THEN 'rejected'
+        ELSE status END;
+
+ALTER TABLE applications ADD CONSTRAINT applications_status_check
+    CHECK (status IN ('pending', 'revision', 'approved', 'rejected'));
+ALTER TABLE applications ADD CONSTRAINT applications_project_duration_check
+    CHECK (project_duration IN ('one_semester', 'two_semesters'));
+ALTER TABLE applications ADD CONSTRAINT applications_project_level_check
+    CHECK (project_level IN ('diagnostic', 'educational', 'educational_applied', 'applied'));
+
+UPDATE application_status_history SET
+    from_status = CASE from_status
+        WHEN 'На рассмотрении' THEN 'pending'
+        WHEN 'На доработке' THEN 'revision'
+        WHEN 'Допущена' THEN 'approved'
+        WHEN 'Отклонена' THEN 'rejected'
+        ELSE from_status END,
+    to_status = CASE to_status
+        WHEN 'На рассмотрении' THEN 'pending'
+        WHEN 'На доработке' THEN 'revision'
+        WHEN 'Допущена' THEN 'approved'
+        WHEN 'Отклонена' THEN 'rejected'
+        ELSE to_status END;
+
+ALTER TABLE application_revisions DISABLE TRIGGER application_revisions_immutable;
+
+UPDATE application_revisions SET
+    before = CASE WHEN before->>'ProjectDuration' IS NULL THEN before ELSE jsonb_set(jsonb_set(before,
+        '{ProjectDuration}', to_jsonb(CASE before->>'ProjectDuration'
+            WHEN '1 семестр' THEN 'one_semester'
+            WHEN '2 семестра' THEN 'two_semesters'
+            ELSE before->>'ProjectDuration' END)),
+        '{ProjectLevel}', to_jsonb(CASE before->>'ProjectLevel'
+            WHEN 'Диагностический проект' THEN 'diagnostic'
+            WHEN 'Учебный проект' THEN 'educational'
+            WHEN 'Учебно-прикладной проект' THEN 'educational_applied'
+            WHEN 'Прикладной проект' THEN 'applied'
+            ELSE before->>'ProjectLevel' END)) END,
+    after = CASE WHEN after->>'ProjectDuration' IS NULL THEN after ELSE jsonb_set(jsonb_set(after,
+        '{ProjectDuration}', to_jsonb(CASE after->>'ProjectDuration'
+            WHEN '1 семестр' THEN 'one_semester'
+            WHEN '2 семестра' THEN 'two_semesters'
+            ELSE after->>'ProjectDuration' END)),
+        '{ProjectLevel}', to_jsonb(CASE after->>'ProjectLevel'
+            WHEN 'Диагностический проект' THEN 'diagnostic'
+            WHEN 'Учебный проект' THEN 'educational'
+            WHEN 'Учебно-прикладной проект' THEN 'educational_applied'
+            WHEN 'Прикладной проект' THEN 'applied'
+            ELSE after->>'ProjectLevel' END)) END;
+
+ALTER TABLE application_revisions ENABLE TRIGGER application_revisions_immutable;
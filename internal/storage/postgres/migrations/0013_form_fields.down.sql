@@ -0,0 +1,3 @@
+ALTER TABLE applications DROP COLUMN answers;
+
+DROP TABLE form_fields;
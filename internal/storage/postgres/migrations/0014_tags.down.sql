@@ -0,0 +1,3 @@
+DROP TABLE application_tags;
+DROP TABLE tag_synonyms;
+DROP TABLE tags;
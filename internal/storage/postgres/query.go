@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/keywords"
+	"strings"
+)
+
+// statusOrder ranks the statuses for sorting by status.
+var statusOrder = fmt.Sprintf(`CASE status WHEN '%s' THEN 0 WHEN '%s' THEN 1 WHEN '%s' THEN 2 WHEN '%s' THEN 3 END`,
+	models.StatusPending, models.StatusRevision, models.StatusRejected, models.StatusApproved)
+
+// levelOrder ranks the project levels for sorting by level, in the order of the form.
+var levelOrder = fmt.Sprintf(`CASE project_level WHEN '%s' THEN 0 WHEN '%s' THEN 1 WHEN '%s' THEN 2 WHEN '%s' THEN 3 END`,
+	models.LevelDiagnostic, models.LevelEducational, models.LevelEducationalApplied, models.LevelApplied)
+
+// applicationsWhere builds the WHERE clause for the filters of q and appends its arguments to args,
+// numbering the placeholders after the existing arguments. Applications in the trash never match.
+func applicationsWhere(q models.ApplicationQuery, args []any) (string, []any) {
+	conditions := []string{"deleted_at IS NULL"}
+
+	in := func(column string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+
+		placeholders := make([]string, 0, len(values))
+		for _, value := range values {
+			args = append(args, value)
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+
+		conditions = append(conditions, fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ",")))
+	}
+
+	in("status", models.Codes(q.Statuses))
+	in("project_level", models.Codes(q.ProjectLevels))
+	in("project_duration", models.Codes(q.ProjectDurations))
+
+	if !q.SubmittedFrom.IsZero() {
+		args = append(args, q.SubmittedFrom)
+		conditions = append(conditions, fmt.Sprintf("submission_date >= $%d", len(args)))
+	}
+
+	if !q.SubmittedTo.IsZero() {
+		args = append(args, q.SubmittedTo)
+		conditions = append(conditions, fmt.Sprintf("submission_date < $%d", len(args)))
+	}
+
+	if q.Tag != "" {
+		args = append(args, keywords.Key(q.Tag))
+		conditions = append(conditions, fmt.Sprintf(
+			"applications.id IN (SELECT application_id FROM application_tags WHERE tag_id IN ("+
+				"SELECT id FROM tags WHERE key = $%[1]d UNION SELECT tag_id FROM tag_synonyms WHERE key = $%[1]d))", len(args)))
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// applicationsOrderBy builds the ORDER BY clause for the sort field of q.
+func applicationsOrderBy(q models.ApplicationQuery) string {
+	direction := "ASC"
+	if q.SortDesc {
+		direction = "DESC"
+	}
+
+	switch q.SortBy {
+	case models.SortBySubmissionDate, models.SortByProjectName:
+		return fmt.Sprintf(" ORDER BY %s %s, id %s", q.SortBy, direction, direction)
+	case models.SortByProjectLevel:
+		return fmt.Sprintf(" ORDER BY %s %s, id %s", levelOrder, direction, direction)
+	case models.SortByID:
+		return " ORDER BY id " + direction
+	case models.SortByRelevance:
+		// ts_rank returns higher values for better matches.
+		if q.SortDesc {
+			return " ORDER BY relevance, id"
+		}
+
+		return " ORDER BY relevance DESC, id"
+	default:
+		return fmt.Sprintf(" ORDER BY %s %s, submission_date, id", statusOrder, direction)
+	}
+}
+
+// applicationColumns lists the application columns in the order scanApplication reads them.
+const applicationColumns = `id,
+		public_id,
+		applicant_name,
+		applicant_email,
+		applicant_phone,
+		position_and_organization,
+		project_duration,
+		project_level,
+		problem_holder,
+		project_goal,
+		barrier,
+		existing_solutions,
+		keywords,
+		interested_parties,
+		consultants,
+		additional_materials,
+		project_name,
+		answers,
+		status,
+		submission_date,
+		revision`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanApplication reads the applicationColumns of the row into application
+// and the columns selected after them into extra.
+func scanApplication(row rowScanner, application *models.Application, extra ...any) error {
+	dest := []any{
+		&application.ID,
+		&application.PublicID,
+		&application.ApplicantName,
+		&application.ApplicantEmail,
+		&application.ApplicantPhone,
+		&application.PositionAndOrganization,
+		&application.ProjectDuration,
+		&application.ProjectLevel,
+		&application.ProblemHolder,
+		&application.ProjectGoal,
+		&application.Barrier,
+		&application.ExistingSolutions,
+		&application.Keywords,
+		&application.InterestedParties,
+		&application.Consultants,
+		&application.AdditionalMaterials,
+		&application.ProjectName,
+		&application.Answers,
+		&application.Status,
+		&application.SubmissionDate,
+		&application.Revision,
+	}
+
+	return row.Scan(append(dest, extra...)...)
+}
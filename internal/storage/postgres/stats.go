@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+)
+
+// CountApplicationsByStatus returns the number of applications in each status, the trash is not counted.
+func (s *Storage) CountApplicationsByStatus(ctx context.Context) (_ map[models.Status]int, err error) {
+	const op = "storage.postgres.CountApplicationsByStatus"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM applications WHERE deleted_at IS NULL GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[models.Status]int)
+
+	for rows.Next() {
+		var (
+			status models.Status
+			count  int
+		)
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		counts[status] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,397 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/keywords"
+	"projectsShowcase/internal/storage"
+)
+
+// tagByKey selects the ID of the tag with the name or synonym given by its key.
+const tagByKey = `SELECT id FROM tags WHERE key = $1 UNION SELECT tag_id FROM tag_synonyms WHERE key = $1`
+
+// GetApprovedTags returns the tags of the approved applications with the number of applications
+// having each of them, the most used first.
+func (s *Storage) GetApprovedTags(ctx context.Context) (_ []models.Tag, err error) {
+	const op = "storage.postgres.GetApprovedTags"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT t.id, t.name, COUNT(*)
+		FROM tags t
+		JOIN application_tags apt ON apt.tag_id = t.id
+		JOIN applications a ON a.id = apt.application_id
+		WHERE a.status = $1 AND a.deleted_at IS NULL
+		GROUP BY t.id, t.name
+		ORDER BY COUNT(*) DESC, t.key`, models.StatusApproved)
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	tags := []models.Tag{}
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Count); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	return tags, nil
+}
+
+// GetTags returns all tags with their synonyms and the number of applications not in the trash
+// having each of them, by name.
+func (s *Storage) GetTags(ctx context.Context) (_ []models.Tag, err error) {
+	const op = "storage.postgres.GetTags"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT t.id, t.name, COUNT(a.id)
+		FROM tags t
+		LEFT JOIN application_tags apt ON apt.tag_id = t.id
+		LEFT JOIN applications a ON a.id = apt.application_id AND a.deleted_at IS NULL
+		GROUP BY t.id, t.name
+		ORDER BY t.key`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	tags := []models.Tag{}
+	byID := map[int64]int{}
+	for rows.Next() {
+		tag := models.Tag{Synonyms: []string{}}
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Count); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		byID[tag.ID] = len(tags)
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	synonyms, err := s.db.QueryContext(ctx, `SELECT tag_id, name FROM tag_synonyms ORDER BY key`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: get synonyms: %w", op, err)
+	}
+	defer synonyms.Close()
+
+	for synonyms.Next() {
+		var (
+			tagID int64
+			name  string
+		)
+		if err := synonyms.Scan(&tagID, &name); err != nil {
+			return nil, fmt.Errorf("%s: scan synonym: %w", op, err)
+		}
+		if i, ok := byID[tagID]; ok {
+			tags[i].Synonyms = append(tags[i].Synonyms, name)
+		}
+	}
+	if err := synonyms.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate synonyms: %w", op, err)
+	}
+
+	return tags, nil
+}
+
+// RenameTag changes the name of the tag. The old name is kept as a synonym,
+// so that the applications using it keep the tag.
+//
+// It returns storage.ErrTagNotFound if there is no such tag and storage.ErrTagExists
+// if another tag has the name or the synonym.
+func (s *Storage) RenameTag(ctx context.Context, id int64, name string) (err error) {
+	const op = "storage.postgres.RenameTag"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var currentName, currentKey string
+
+	err = tx.QueryRowContext(ctx, `SELECT name, key FROM tags WHERE id = $1`, id).Scan(&currentName, &currentKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrTagNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: get tag: %w", op, err)
+	}
+
+	key := keywords.Key(name)
+
+	if key != currentKey {
+		var other int64
+
+		err := tx.QueryRowContext(ctx, tagByKey, key).Scan(&other)
+		if err == nil && other != id {
+			return fmt.Errorf("%s: %w", op, storage.ErrTagExists)
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: find tag: %w", op, err)
+		}
+
+		// A synonym of the tag may become its name.
+		if _, err := tx.ExecContext(ctx, `DELETE FROM tag_synonyms WHERE key = $1`, key); err != nil {
+			return fmt.Errorf("%s: delete synonym: %w", op, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO tag_synonyms(key, name, tag_id) VALUES($1, $2, $3)`, currentKey, currentName, id)
+		if err != nil {
+			return fmt.Errorf("%s: keep old name: %w", op, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE tags SET name = $1, key = $2 WHERE id = $3`, name, key, id); err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// MergeTags moves the applications and the synonyms of the source tag to the target tag
+// and deletes the source tag, its name becomes a synonym of the target.
+//
+// It returns storage.ErrTagNotFound if there is no source tag, storage.ErrTagMergeTarget
+// if there is no target tag and storage.ErrTagMergeSelf if they are the same tag.
+func (s *Storage) MergeTags(ctx context.Context, sourceID, targetID int64) (err error) {
+	const op = "storage.postgres.MergeTags"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	if sourceID == targetID {
+		return storage.ErrTagMergeSelf
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	exists, err := tagExists(ctx, tx, sourceID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return storage.ErrTagNotFound
+	}
+
+	exists, err = tagExists(ctx, tx, targetID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return storage.ErrTagMergeTarget
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO application_tags(application_id, tag_id)
+		SELECT application_id, $1::bigint FROM application_tags WHERE tag_id = $2
+		ON CONFLICT DO NOTHING`, targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("%s: move applications: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE tag_synonyms SET tag_id = $1 WHERE tag_id = $2`, targetID, sourceID); err != nil {
+		return fmt.Errorf("%s: move synonyms: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO tag_synonyms(key, name, tag_id) SELECT key, name, $1::bigint FROM tags WHERE id = $2`, targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("%s: keep source name: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tags WHERE id = $1`, sourceID); err != nil {
+		return fmt.Errorf("%s: delete source tag: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddTagSynonym makes the term name the tag too.
+//
+// It returns storage.ErrTagNotFound if there is no such tag and storage.ErrTagExists
+// if a tag or a synonym already has the name, tags are merged with MergeTags instead.
+func (s *Storage) AddTagSynonym(ctx context.Context, id int64, name string) (err error) {
+	const op = "storage.postgres.AddTagSynonym"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	exists, err := tagExists(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return storage.ErrTagNotFound
+	}
+
+	key := keywords.Key(name)
+
+	var other int64
+
+	err = tx.QueryRowContext(ctx, tagByKey, key).Scan(&other)
+	if err == nil {
+		return fmt.Errorf("%s: %w", op, storage.ErrTagExists)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%s: find tag: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO tag_synonyms(key, name, tag_id) VALUES($1, $2, $3)`, key, name, id); err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteTagSynonym removes a synonym of the tag. Applications tagged through it keep the tag
+// until their keywords are saved again.
+//
+// It returns storage.ErrTagSynonymNotFound if the tag has no such synonym.
+func (s *Storage) DeleteTagSynonym(ctx context.Context, id int64, name string) (err error) {
+	const op = "storage.postgres.DeleteTagSynonym"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM tag_synonyms WHERE tag_id = $1 AND key = $2`, id, keywords.Key(name))
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get rows affected: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return storage.ErrTagSynonymNotFound
+	}
+
+	return nil
+}
+
+// RebuildTags parses the keywords of every application, the ones in the trash included, into tags again
+// and returns the number of applications.
+func (s *Storage) RebuildTags(ctx context.Context) (_ int, err error) {
+	const op = "storage.postgres.RebuildTags"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	type application struct {
+		id       int64
+		keywords sql.NullString
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, keywords FROM applications ORDER BY id`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: get applications: %w", op, err)
+	}
+
+	var applications []application
+	for rows.Next() {
+		var a application
+		if err := rows.Scan(&a.id, &a.keywords); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		applications = append(applications, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	for _, a := range applications {
+		if err := syncTags(ctx, tx, a.id, a.keywords.String); err != nil {
+			return 0, fmt.Errorf("%s: application %d: %w", op, a.id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: commit transaction: %w", op, err)
+	}
+
+	return len(applications), nil
+}
+
+// tagExists reports whether there is a tag with the ID, in the transaction.
+func tagExists(ctx context.Context, tx *sql.Tx, id int64) (bool, error) {
+	var exists bool
+
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM tags WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return false, fmt.Errorf("get tag: %w", err)
+	}
+
+	return exists, nil
+}
+
+// syncTags replaces the tags of the application with the ones its keywords name, in the transaction.
+// Terms that name no tag create one.
+func syncTags(ctx context.Context, tx *sql.Tx, applicationID int64, applicationKeywords string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM application_tags WHERE application_id = $1`, applicationID); err != nil {
+		return fmt.Errorf("delete tags: %w", err)
+	}
+
+	for _, term := range keywords.Split(applicationKeywords) {
+		key := keywords.Key(term)
+
+		var tagID int64
+
+		err := tx.QueryRowContext(ctx, tagByKey, key).Scan(&tagID)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = tx.QueryRowContext(ctx, `INSERT INTO tags(name, key) VALUES($1, $2)
+				ON CONFLICT (key) DO NOTHING RETURNING id`, term, key).Scan(&tagID)
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			// Created concurrently by another application.
+			err = tx.QueryRowContext(ctx, tagByKey, key).Scan(&tagID)
+		}
+		if err != nil {
+			return fmt.Errorf("resolve tag %q: %w", term, err)
+		}
+
+		// Different terms may name the same tag through synonyms.
+		_, err = tx.ExecContext(ctx, `INSERT INTO application_tags(application_id, tag_id) VALUES($1, $2) ON CONFLICT DO NOTHING`, applicationID, tagID)
+		if err != nil {
+			return fmt.Errorf("tag application: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/storage"
+	"time"
+)
+
+// DeleteApplication moves the application to the trash, recording when and by whom it was deleted.
+//
+// It returns storage.ErrApplicationNotFound if there is no such application or it is already in the trash.
+func (s *Storage) DeleteApplication(ctx context.Context, id int64, deletedBy string) (err error) {
+	const op = "storage.postgres.DeleteApplication"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	res, err := s.db.ExecContext(ctx, `UPDATE applications SET deleted_at = CURRENT_TIMESTAMP, deleted_by = $1
+		WHERE id = $2 AND deleted_at IS NULL`, deletedBy, id)
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get rows affected: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return storage.ErrApplicationNotFound
+	}
+
+	return nil
+}
+
+// RestoreApplication takes the application out of the trash.
+//
+// It returns storage.ErrApplicationNotFound if there is no such application in the trash.
+func (s *Storage) RestoreApplication(ctx context.Context, id int64) (err error) {
+	const op = "storage.postgres.RestoreApplication"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	res, err := s.db.ExecContext(ctx, `UPDATE applications SET deleted_at = NULL, deleted_by = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get rows affected: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return storage.ErrApplicationNotFound
+	}
+
+	return nil
+}
+
+// GetDeletedApplications returns a page of the applications in the trash, the most recently deleted first,
+// together with the total number of applications in the trash.
+func (s *Storage) GetDeletedApplications(ctx context.Context, limit, offset int) (_ []models.DeletedApplication, _ int, err error) {
+	const op = "storage.postgres.GetDeletedApplications"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	var total int
+
+	err = s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM applications WHERE deleted_at IS NOT NULL`).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: count applications: %w", op, err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+applicationColumns+`, deleted_at, deleted_by
+		FROM applications WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	applications := []models.DeletedApplication{}
+
+	for rows.Next() {
+		var application models.DeletedApplication
+		err = scanApplication(rows, &application.Application, &application.DeletedAt, &application.DeletedBy)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		applications = append(applications, application)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	return applications, total, nil
+}
+
+// PurgeDeletedApplications deletes the applications moved to the trash before the given time for good,
+// together with their history, revisions, notifications and attachments, and returns their number.
+//
+// The blobs of the attachments no other application shares are marked as orphaned, the blob collector
+// deletes them once they have stayed unused for its grace period.
+func (s *Storage) PurgeDeletedApplications(ctx context.Context, deletedBefore time.Time) (_ int64, err error) {
+	const op = "storage.postgres.PurgeDeletedApplications"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	before := deletedBefore
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO orphan_blobs(blob_key)
+		SELECT DISTINCT blob_key FROM attachments
+		WHERE application_id IN (SELECT id FROM applications WHERE deleted_at < $1)
+		AND blob_key NOT IN (
+			SELECT blob_key FROM attachments
+			WHERE application_id NOT IN (SELECT id FROM applications WHERE deleted_at < $1)
+		)
+		ON CONFLICT(blob_key) DO UPDATE SET marked_at = CURRENT_TIMESTAMP`, before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: mark blobs: %w", op, err)
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM applications WHERE deleted_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get rows affected: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: commit transaction: %w", op, err)
+	}
+
+	return purged, nil
+}
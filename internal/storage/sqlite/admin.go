@@ -0,0 +1,180 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/storage"
+	"time"
+)
+
+// SaveAdminUser saves an admin user with an already hashed password and returns its ID.
+//
+// It returns storage.ErrAdminUserExists if the login is taken and storage.ErrAdminRole for an unknown role.
+func (s *Storage) SaveAdminUser(ctx context.Context, login, passwordHash, role string) (_ int64, err error) {
+	const op = "storage.sqlite.SaveAdminUser"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	res, err := s.db.ExecContext(ctx, `INSERT INTO admin_users(login, password_hash, role) VALUES(?, ?, ?)`,
+		login, passwordHash, role)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrAdminUserExists)
+		}
+		if constraintErr := constraintError(err); constraintErr != nil {
+			return 0, fmt.Errorf("%s: %w", op, constraintErr)
+		}
+
+		return 0, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get last insert id: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetAdminUserByLogin(ctx context.Context, login string) (_ *models.AdminUser, err error) {
+	const op = "storage.sqlite.GetAdminUserByLogin"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	var user models.AdminUser
+
+	err = s.db.QueryRowContext(ctx, `SELECT id, login, password_hash, role, created_at FROM admin_users WHERE login = ?`, login).
+		Scan(&user.ID, &user.Login, &user.PasswordHash, &user.Role, &user.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrAdminUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+// UpdateAdminUserPassword replaces the password hash of the admin and signs them out everywhere.
+func (s *Storage) UpdateAdminUserPassword(ctx context.Context, login, passwordHash string) (err error) {
+	const op = "storage.sqlite.UpdateAdminUserPassword"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var id int64
+
+	err = tx.QueryRowContext(ctx, `UPDATE admin_users SET password_hash = ? WHERE login = ? RETURNING id`, passwordHash, login).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrAdminUserNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM admin_sessions WHERE admin_user_id = ?`, id); err != nil {
+		return fmt.Errorf("%s: delete sessions: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) CountAdminUsers(ctx context.Context) (_ int, err error) {
+	const op = "storage.sqlite.CountAdminUsers"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	var count int
+
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM admin_users`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return count, nil
+}
+
+func (s *Storage) SaveAdminSession(ctx context.Context, tokenHash string, adminUserID int64, expiresAt time.Time) (err error) {
+	const op = "storage.sqlite.SaveAdminSession"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO admin_sessions(token_hash, admin_user_id, expires_at) VALUES(?, ?, ?)`,
+		tokenHash, adminUserID, expiresAt.UTC().Format(timeFormat))
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetAdminSession returns the session with the given token hash together with its admin.
+//
+// Expired sessions are returned as well, checking the expiry is up to the caller.
+func (s *Storage) GetAdminSession(ctx context.Context, tokenHash string) (_ *models.AdminSession, err error) {
+	const op = "storage.sqlite.GetAdminSession"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	var session models.AdminSession
+
+	err = s.db.QueryRowContext(ctx, `SELECT
+		u.id,
+		u.login,
+		u.password_hash,
+		u.role,
+		u.created_at,
+		s.expires_at
+		FROM admin_sessions s JOIN admin_users u ON u.id = s.admin_user_id
+		WHERE s.token_hash = ?`, tokenHash).Scan(
+		&session.AdminUser.ID,
+		&session.AdminUser.Login,
+		&session.AdminUser.PasswordHash,
+		&session.AdminUser.Role,
+		&session.AdminUser.CreatedAt,
+		&session.ExpiresAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &session, nil
+}
+
+func (s *Storage) DeleteAdminSession(ctx context.Context, tokenHash string) (err error) {
+	const op = "storage.sqlite.DeleteAdminSession"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM admin_sessions WHERE token_hash = ?`, tokenHash); err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteExpiredAdminSessions(ctx context.Context, now time.Time) (err error) {
+	const op = "storage.sqlite.DeleteExpiredAdminSessions"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM admin_sessions WHERE expires_at <= ?`, now.UTC().Format(timeFormat)); err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"errors"
+	"projectsShowcase/internal/storage"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// constraintError translates a CHECK constraint violation into the matching storage error.
+//
+// It returns nil if err is not a violation of one of the known constraints.
+func constraintError(err error) error {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.ExtendedCode != sqlite3.ErrConstraintCheck {
+		return nil
+	}
+
+	// SQLite names unnamed CHECK constraints after their expression.
+	msg := sqliteErr.Error()
+	switch {
+	case strings.Contains(msg, "project_duration"):
+		return storage.ErrProjectDuration
+	case strings.Contains(msg, "project_level"):
+		return storage.ErrProjectLevel
+	case strings.Contains(msg, "status"):
+		return storage.ErrProjectStatus
+	case strings.Contains(msg, "role"):
+		return storage.ErrAdminRole
+	}
+
+	return nil
+}
+
+// isUniqueViolation reports whether err is a violation of a UNIQUE or PRIMARY KEY constraint.
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+
+	return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+}
@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+)
+
+// ExportApplications calls fn for every application matching the filters of the query, in the order it asks for.
+//
+// The rows are read one at a time, so the applications are never all in memory.
+// Paging and full-text search of the query are ignored. An error returned by fn stops the export and is returned as is.
+func (s *Storage) ExportApplications(ctx context.Context, q models.ApplicationQuery, fn func(models.Application) error) (err error) {
+	const op = "storage.sqlite.ExportApplications"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	where, args := applicationsWhere(q)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+applicationColumns+`
+		FROM applications`+where+applicationsOrderBy(q), args...)
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var application models.Application
+		if err := scanApplication(rows, &application); err != nil {
+			return fmt.Errorf("%s: scan row: %w", op, err)
+		}
+
+		if err := fn(application); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	return nil
+}
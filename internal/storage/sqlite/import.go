@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+)
+
+// ImportApplications saves pending applications in a single transaction and returns their IDs.
+//
+// The fields are recorded as revision 0 edited by the importing admin. No notifications are queued,
+// the applicants didn't submit the applications themselves. If any application is rejected by the schema
+// nothing is saved and the error wraps storage.ErrProjectDuration or storage.ErrProjectLevel.
+func (s *Storage) ImportApplications(ctx context.Context, applications []models.ApplicationFields, importedBy string) (_ []int64, err error) {
+	const op = "storage.sqlite.ImportApplications"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, 0, len(applications))
+
+	for i, fields := range applications {
+		id, _, err := insertApplication(ctx, tx, fields, models.StatusPending)
+		if err != nil {
+			return nil, fmt.Errorf("%s: application %d: %w", op, i+1, err)
+		}
+
+		if err := recordRevision(ctx, tx, id, 0, models.AuthorAdmin, importedBy, nil, fields); err != nil {
+			return nil, fmt.Errorf("%s: application %d: %w", op, i+1, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: commit transaction: %w", op, err)
+	}
+
+	return ids, nil
+}
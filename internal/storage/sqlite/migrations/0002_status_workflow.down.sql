@@ -0,0 +1,34 @@
+DROP TABLE application_status_history;
+
+CREATE TABLE applications_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    applicant_name TEXT NOT NULL,
+    applicant_email TEXT NOT NULL,
+    applicant_phone TEXT NOT NULL,
+    position_and_organization TEXT NOT NULL,
+    project_duration TEXT CHECK(project_duration IN ('1 семестр', '2 семестра')) NOT NULL,
+    project_level TEXT CHECK(project_level IN ('Диагностический проект', 'Учебный проект', 'Учебно-прикладной проект', 'Прикладной проект')) NOT NULL,
+    problem_holder TEXT NOT NULL,
+    project_goal TEXT NOT NULL,
+    barrier TEXT NOT NULL,
+    existing_solutions TEXT NOT NULL,
+    keywords TEXT,
+    interested_parties TEXT,
+    consultants TEXT,
+    additional_materials TEXT,
+    project_name TEXT NOT NULL,
+    status TEXT CHECK(status IN ('На рассмотрении', 'Допущена', 'Удалена')) NOT NULL,
+    submission_date DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO applications_old
+SELECT id, applicant_name, applicant_email, applicant_phone, position_and_organization, project_duration,
+       project_level, problem_holder, project_goal, barrier, existing_solutions, keywords, interested_parties,
+       consultants, additional_materials, project_name,
+       CASE WHEN status IN ('На доработке', 'Отклонена') THEN 'На рассмотрении' ELSE status END,
+       submission_date
+FROM applications;
+
+DROP TABLE applications;
+
+ALTER TABLE applications_old RENAME TO applications;
@@ -0,0 +1,38 @@
+CREATE TABLE applications_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    applicant_name TEXT NOT NULL,
+    applicant_email TEXT NOT NULL,
+    applicant_phone TEXT NOT NULL,
+    position_and_organization TEXT NOT NULL,
+    project_duration TEXT CHECK(project_duration IN ('1 семестр', '2 семестра')) NOT NULL,
+    project_level TEXT CHECK(project_level IN ('Диагностический проект', 'Учебный проект', 'Учебно-прикладной проект', 'Прикладной проект')) NOT NULL,
+    problem_holder TEXT NOT NULL,
+    project_goal TEXT NOT NULL,
+    barrier TEXT NOT NULL,
+    existing_solutions TEXT NOT NULL,
+    keywords TEXT,
+    interested_parties TEXT,
+    consultants TEXT,
+    additional_materials TEXT,
+    project_name TEXT NOT NULL,
+    status TEXT CHECK(status IN ('На рассмотрении', 'На доработке', 'Допущена', 'Отклонена', 'Удалена')) NOT NULL,
+    submission_date DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO applications_new SELECT * FROM applications;
+
+DROP TABLE applications;
+
+ALTER TABLE applications_new RENAME TO applications;
+
+CREATE TABLE application_status_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    changed_by TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_application_status_history_application_id ON application_status_history(application_id);
@@ -0,0 +1,4 @@
+DROP TRIGGER applications_fts_update;
+DROP TRIGGER applications_fts_delete;
+DROP TRIGGER applications_fts_insert;
+DROP TABLE applications_fts;
@@ -0,0 +1,30 @@
+-- Requires SQLite built with FTS5 (go build -tags sqlite_fts5).
+CREATE VIRTUAL TABLE applications_fts USING fts5(
+    project_name,
+    project_goal,
+    barrier,
+    existing_solutions,
+    keywords,
+    content = 'applications',
+    content_rowid = 'id',
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+INSERT INTO applications_fts(applications_fts) VALUES ('rebuild');
+
+CREATE TRIGGER applications_fts_insert AFTER INSERT ON applications BEGIN
+    INSERT INTO applications_fts(rowid, project_name, project_goal, barrier, existing_solutions, keywords)
+    VALUES (new.id, new.project_name, new.project_goal, new.barrier, new.existing_solutions, new.keywords);
+END;
+
+CREATE TRIGGER applications_fts_delete AFTER DELETE ON applications BEGIN
+    INSERT INTO applications_fts(applications_fts, rowid, project_name, project_goal, barrier, existing_solutions, keywords)
+    VALUES ('delete', old.id, old.project_name, old.project_goal, old.barrier, old.existing_solutions, old.keywords);
+END;
+
+CREATE TRIGGER applications_fts_update AFTER UPDATE ON applications BEGIN
+    INSERT INTO applications_fts(applications_fts, rowid, project_name, project_goal, barrier, existing_solutions, keywords)
+    VALUES ('delete', old.id, old.project_name, old.project_goal, old.barrier, old.existing_solutions, old.keywords);
+    INSERT INTO applications_fts(rowid, project_name, project_goal, barrier, existing_solutions, keywords)
+    VALUES (new.id, new.project_name, new.project_goal, new.barrier, new.existing_solutions, new.keywords);
+END;
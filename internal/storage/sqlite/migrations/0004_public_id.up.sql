@@ -0,0 +1,12 @@
+ALTER TABLE applications ADD COLUMN public_id TEXT;
+
+-- Random version 4 UUIDs for the existing applications, new ones get theirs from the application.
+UPDATE applications SET public_id =
+    lower(hex(randomblob(4))) || '-' ||
+    lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' ||
+    substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' ||
+    lower(hex(randomblob(6)))
+WHERE public_id IS NULL;
+
+CREATE UNIQUE INDEX idx_applications_public_id ON applications(public_id);
@@ -0,0 +1,13 @@
+ALTER TABLE applications ADD COLUMN revision INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE application_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    author_type TEXT NOT NULL CHECK (author_type IN ('applicant', 'admin')),
+    edited_by TEXT NOT NULL,
+    before TEXT NOT NULL,
+    after TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (application_id, revision)
+);
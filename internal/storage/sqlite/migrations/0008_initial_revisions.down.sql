@@ -0,0 +1,3 @@
+DROP TRIGGER application_revisions_immutable;
+
+DELETE FROM application_revisions WHERE revision = 0;
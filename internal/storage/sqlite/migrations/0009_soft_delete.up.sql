@@ -0,0 +1,19 @@
+ALTER TABLE applications ADD COLUMN deleted_at DATETIME;
+ALTER TABLE applications ADD COLUMN deleted_by TEXT;
+
+-- Applications deleted with the old 'Удалена' status go to the trash, they are restored as pending.
+-- The retention period starts with the migration, so they aren't purged as soon as it is enabled.
+UPDATE applications SET
+    deleted_at = CURRENT_TIMESTAMP,
+    deleted_by = COALESCE((SELECT changed_by FROM application_status_history h
+        WHERE h.application_id = applications.id AND h.to_status = 'Удалена'
+        ORDER BY changed_at DESC, id DESC LIMIT 1), '')
+WHERE status = 'Удалена';
+
+INSERT INTO application_status_history(application_id, from_status, to_status, changed_by, reason)
+SELECT id, status, 'На рассмотрении', deleted_by, 'moved to the trash'
+FROM applications WHERE status = 'Удалена';
+
+UPDATE applications SET status = 'На рассмотрении' WHERE status = 'Удалена';
+
+CREATE INDEX idx_applications_deleted_at ON applications(deleted_at);
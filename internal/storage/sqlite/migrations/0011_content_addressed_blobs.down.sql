@@ -0,0 +1,18 @@
+-- Fails while attachments share a blob.
+CREATE TABLE attachments_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    blob_key TEXT NOT NULL UNIQUE,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO attachments_new SELECT * FROM attachments;
+
+DROP TABLE attachments;
+
+ALTER TABLE attachments_new RENAME TO attachments;
+
+CREATE INDEX idx_attachments_application_id ON attachments(application_id);
@@ -0,0 +1,19 @@
+-- Attachment content is stored under its SHA-256, attachments with the same content share a blob.
+CREATE TABLE attachments_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    blob_key TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO attachments_new SELECT * FROM attachments;
+
+DROP TABLE attachments;
+
+ALTER TABLE attachments_new RENAME TO attachments;
+
+CREATE INDEX idx_attachments_application_id ON attachments(application_id);
+CREATE INDEX idx_attachments_blob_key ON attachments(blob_key);
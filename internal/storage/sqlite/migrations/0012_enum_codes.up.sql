@@ -0,0 +1,142 @@
+-- Enum values are stored as language-neutral codes, the labels are in the application.
+-- SQLite can't alter a CHECK constraint, so the applications table is rebuilt with the same columns.
+
+CREATE TABLE applications_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    applicant_name TEXT NOT NULL,
+    applicant_email TEXT NOT NULL,
+    applicant_phone TEXT NOT NULL,
+    position_and_organization TEXT NOT NULL,
+    project_duration TEXT CHECK(project_duration IN ('one_semester', 'two_semesters')) NOT NULL,
+    project_level TEXT CHECK(project_level IN ('diagnostic', 'educational', 'educational_applied', 'applied')) NOT NULL,
+    problem_holder TEXT NOT NULL,
+    project_goal TEXT NOT NULL,
+    barrier TEXT NOT NULL,
+    existing_solutions TEXT NOT NULL,
+    keywords TEXT,
+    interested_parties TEXT,
+    consultants TEXT,
+    additional_materials TEXT,
+    project_name TEXT NOT NULL,
+    status TEXT CHECK(status IN ('pending', 'revision', 'approved', 'rejected')) NOT NULL,
+    submission_date DATETIME DEFAULT CURRENT_TIMESTAMP,
+    public_id TEXT,
+    revision INTEGER NOT NULL DEFAULT 0,
+    deleted_at DATETIME,
+    deleted_by TEXT
+);
+
+INSERT INTO applications_new(
+    id, applicant_name, applicant_email, applicant_phone, position_and_organization,
+    project_duration, project_level, problem_holder, project_goal, barrier, existing_solutions,
+    keywords, interested_parties, consultants, additional_materials, project_name,
+    status, submission_date, public_id, revision, deleted_at, deleted_by
+)
+SELECT
+    id,
+    applicant_name,
+    applicant_email,
+    applicant_phone,
+    position_and_organization,
+    CASE project_duration
+        WHEN '1 семестр' THEN 'one_semester'
+        WHEN '2 семестра' THEN 'two_semesters'
+        ELSE project_duration END,
+    CASE project_level
+        WHEN 'Диагностический проект' THEN 'diagnostic'
+        WHEN 'Учебный проект' THEN 'educational'
+        WHEN 'Учебно-прикладной проект' THEN 'educational_applied'
+        WHEN 'Прикладной проект' THEN 'applied'
+        ELSE project_level END,
+    problem_holder,
+    project_goal,
+    barrier,
+    existing_solutions,
+    keywords,
+    interested_parties,
+    consultants,
+    additional_materials,
+    project_name,
+    CASE status
+        WHEN 'На рассмотрении' THEN 'pending'
+        WHEN 'На доработке' THEN 'revision'
+        WHEN 'Допущена' THEN 'approved'
+        WHEN 'Отклонена' THEN 'rejected'
+        ELSE status END,
+    submission_date,
+    public_id,
+    revision,
+    deleted_at,
+    deleted_by
+FROM applications;
+
+DROP TABLE applications;
+
+ALTER TABLE applications_new RENAME TO applications;
+
+CREATE UNIQUE INDEX idx_applications_public_id ON applications(public_id);
+CREATE INDEX idx_applications_deleted_at ON applications(deleted_at);
+
+CREATE TRIGGER applications_fts_insert AFTER INSERT ON applications BEGIN
+    INSERT INTO applications_fts(rowid, project_name, project_goal, barrier, existing_solutions, keywords)
+    VALUES (new.id, new.project_name, new.project_goal, new.barrier, new.existing_solutions, new.keywords);
+END;
+
+CREATE TRIGGER applications_fts_delete AFTER DELETE ON applications BEGIN
+    INSERT INTO applications_fts(applications_fts, rowid, project_name, project_goal, barrier, existing_solutions, keywords)
+    VALUES ('delete', old.id, old.project_name, old.project_goal, old.barrier, old.existing_solutions, old.keywords);
+END;
+
+CREATE TRIGGER applications_fts_update AFTER UPDATE ON applications BEGIN
+    INSERT INTO applications_fts(applications_fts, rowid, project_name, project_goal, barrier, existing_solutions, keywords)
+    VALUES ('delete', old.id, old.project_name, old.project_goal, old.barrier, old.existing_solutions, old.keywords);
+    INSERT INTO applications_fts(rowid, project_name, project_goal, barrier, existing_solutions, keywords)
+    VALUES (new.id, new.project_name, new.project_goal, new.barrier, new.existing_solutions, new.keywords);
+END;
+
+INSERT INTO applications_fts(applications_fts) VALUES ('rebuild');
+
+UPDATE application_status_history SET
+    from_status = CASE from_status
+        WHEN 'На рассмотрении' THEN 'pending'
+        WHEN 'На доработке' THEN 'revision'
+        WHEN 'Допущена' THEN 'approved'
+        WHEN 'Отклонена' THEN 'rejected'
+        ELSE from_status END,
+    to_status = CASE to_status
+        WHEN 'На рассмотрении' THEN 'pending'
+        WHEN 'На доработке' THEN 'revision'
+        WHEN 'Допущена' THEN 'approved'
+        WHEN 'Отклонена' THEN 'rejected'
+        ELSE to_status END;
+
+DROP TRIGGER application_revisions_immutable;
+
+UPDATE application_revisions SET
+    before = CASE WHEN json_type(before, '$.ProjectDuration') IS NULL THEN before ELSE json_set(before,
+        '$.ProjectDuration', CASE json_extract(before, '$.ProjectDuration')
+            WHEN '1 семестр' THEN 'one_semester'
+            WHEN '2 семестра' THEN 'two_semesters'
+            ELSE json_extract(before, '$.ProjectDuration') END,
+        '$.ProjectLevel', CASE json_extract(before, '$.ProjectLevel')
+            WHEN 'Диагностический проект' THEN 'diagnostic'
+            WHEN 'Учебный проект' THEN 'educational'
+            WHEN 'Учебно-прикладной проект' THEN 'educational_applied'
+            WHEN 'Прикладной проект' THEN 'applied'
+            ELSE json_extract(before, '$.ProjectLevel') END) END,
+    after = CASE WHEN json_type(after, '$.ProjectDuration') IS NULL THEN after ELSE json_set(after,
+        '$.ProjectDuration', CASE json_extract(after, '$.ProjectDuration')
+            WHEN '1 семестр' THEN 'one_semester'
+            WHEN '2 семестра' THEN 'two_semesters'
+            ELSE json_extract(after, '$.ProjectDuration') END,
+        '$.ProjectLevel', CASE json_extract(after, '$.ProjectLevel')
+            WHEN 'Диагностический проект' THEN 'diagnostic'
+            WHEN 'Учебный проект' THEN 'educational'
+            WHEN 'Учебно-прикладной проект' THEN 'educational_applied'
+            WHEN 'Прикладной проект' THEN 'applied'
+            ELSE json_extract(after, '$.ProjectLevel') END) END;
+
+CREATE TRIGGER application_revisions_immutable BEFORE UPDATE ON application_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'application revisions are immutable');
+END;
@@ -0,0 +1,24 @@
+-- Keywords parsed into tags. A term names a tag by its key or by the key of one of its synonyms,
+-- a key is either a tag key or a synonym key. Existing applications are tagged by the migrator right after it is applied.
+CREATE TABLE tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    key TEXT NOT NULL UNIQUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE tag_synonyms (
+    key TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_tag_synonyms_tag_id ON tag_synonyms(tag_id);
+
+CREATE TABLE application_tags (
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (application_id, tag_id)
+);
+
+CREATE INDEX idx_application_tags_tag_id ON application_tags(tag_id);
@@ -0,0 +1 @@
+DROP TABLE orphan_blobs;
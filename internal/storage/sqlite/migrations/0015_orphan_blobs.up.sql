@@ -0,0 +1,6 @@
+-- Blobs that may have no attachment left: of purged applications and of discarded uploads.
+-- The blob collector deletes them once they have been unused for longer than its grace period.
+CREATE TABLE orphan_blobs (
+    blob_key TEXT PRIMARY KEY,
+    marked_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
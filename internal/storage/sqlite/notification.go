@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"time"
+)
+
+// enqueueNotification adds a notification of the given kind for the applicant to the outbox.
+//
+// It is called in the transaction that changes the application, so the notification is only sent if the change is committed.
+func enqueueNotification(ctx context.Context, tx *sql.Tx, applicationID int64, kind string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO notification_outbox(application_id, kind, recipient)
+		SELECT id, ?, applicant_email FROM applications WHERE id = ?`, kind, applicationID)
+	if err != nil {
+		return fmt.Errorf("enqueue notification: %w", err)
+	}
+
+	return nil
+}
+
+// GetPendingNotifications returns up to limit pending notifications due at now, oldest first.
+// Notifications about applications in the trash wait until they are restored or purged.
+func (s *Storage) GetPendingNotifications(ctx context.Context, now time.Time, limit int) (_ []models.Notification, err error) {
+	const op = "storage.sqlite.GetPendingNotifications"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT
+		id,
+		application_id,
+		kind,
+		recipient,
+		status,
+		attempts,
+		last_error,
+		next_attempt_at,
+		created_at
+		FROM notification_outbox WHERE status = ? AND next_attempt_at <= ?
+		AND application_id IN (SELECT id FROM applications WHERE deleted_at IS NULL)
+		ORDER BY id LIMIT ?`, models.NotificationPending, now.UTC().Format(timeFormat), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+
+	for rows.Next() {
+		var notification models.Notification
+		err = rows.Scan(
+			&notification.ID,
+			&notification.ApplicationID,
+			&notification.Kind,
+			&notification.Recipient,
+			&notification.Status,
+			&notification.Attempts,
+			&notification.LastError,
+			&notification.NextAttemptAt,
+			&notification.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	return notifications, nil
+}
+
+func (s *Storage) MarkNotificationSent(ctx context.Context, id int64) (err error) {
+	const op = "storage.sqlite.MarkNotificationSent"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	_, err = s.db.ExecContext(ctx, `UPDATE notification_outbox
+		SET status = ?, attempts = attempts + 1, last_error = '', sent_at = CURRENT_TIMESTAMP
+		WHERE id = ?`, models.NotificationSent, id)
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return nil
+}
+
+// RetryNotification records a failed attempt to send the notification and schedules the next one.
+func (s *Storage) RetryNotification(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) (err error) {
+	const op = "storage.sqlite.RetryNotification"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	_, err = s.db.ExecContext(ctx, `UPDATE notification_outbox
+		SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?
+		WHERE id = ?`, lastError, nextAttemptAt.UTC().Format(timeFormat), id)
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return nil
+}
+
+// FailNotification records a failed attempt to send the notification and gives up on it.
+func (s *Storage) FailNotification(ctx context.Context, id int64, lastError string) (err error) {
+	const op = "storage.sqlite.FailNotification"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	_, err = s.db.ExecContext(ctx, `UPDATE notification_outbox
+		SET status = ?, attempts = attempts + 1, last_error = ?
+		WHERE id = ?`, models.NotificationFailed, lastError, id)
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return nil
+}
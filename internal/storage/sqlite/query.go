@@ -0,0 +1,160 @@
+package sqlite
+
+import (
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/keywords"
+	"strings"
+	"unicode"
+)
+
+// timeFormat is the format SQLite uses for CURRENT_TIMESTAMP.
+const timeFormat = "2006-01-02 15:04:05"
+
+// statusOrder ranks the statuses for sorting by status.
+var statusOrder = fmt.Sprintf(`CASE status WHEN '%s' THEN 0 WHEN '%s' THEN 1 WHEN '%s' THEN 2 WHEN '%s' THEN 3 END`,
+	models.StatusPending, models.StatusRevision, models.StatusRejected, models.StatusApproved)
+
+// levelOrder ranks the project levels for sorting by level, in the order of the form.
+var levelOrder = fmt.Sprintf(`CASE project_level WHEN '%s' THEN 0 WHEN '%s' THEN 1 WHEN '%s' THEN 2 WHEN '%s' THEN 3 END`,
+	models.LevelDiagnostic, models.LevelEducational, models.LevelEducationalApplied, models.LevelApplied)
+
+// tagIDs selects the ID of the tag with the name or synonym given by its key twice.
+const tagIDs = `SELECT id FROM tags WHERE key = ? UNION SELECT tag_id FROM tag_synonyms WHERE key = ?`
+
+// applicationsWhere builds the WHERE clause and its arguments for the filters of q.
+// Applications in the trash never match.
+func applicationsWhere(q models.ApplicationQuery) (string, []any) {
+	var (
+		conditions = []string{"deleted_at IS NULL"}
+		args       []any
+	)
+
+	in := func(column string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+
+		conditions = append(conditions, fmt.Sprintf("%s IN (%s)", column, strings.TrimSuffix(strings.Repeat("?,", len(values)), ",")))
+		for _, value := range values {
+			args = append(args, value)
+		}
+	}
+
+	in("status", models.Codes(q.Statuses))
+	in("project_level", models.Codes(q.ProjectLevels))
+	in("project_duration", models.Codes(q.ProjectDurations))
+
+	if !q.SubmittedFrom.IsZero() {
+		conditions = append(conditions, "submission_date >= ?")
+		args = append(args, q.SubmittedFrom.UTC().Format(timeFormat))
+	}
+
+	if !q.SubmittedTo.IsZero() {
+		conditions = append(conditions, "submission_date < ?")
+		args = append(args, q.SubmittedTo.UTC().Format(timeFormat))
+	}
+
+	if q.Tag != "" {
+		conditions = append(conditions, "applications.id IN (SELECT application_id FROM application_tags WHERE tag_id IN ("+tagIDs+"))")
+		key := keywords.Key(q.Tag)
+		args = append(args, key, key)
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// applicationsOrderBy builds the ORDER BY clause for the sort field of q.
+func applicationsOrderBy(q models.ApplicationQuery) string {
+	direction := "ASC"
+	if q.SortDesc {
+		direction = "DESC"
+	}
+
+	switch q.SortBy {
+	case models.SortBySubmissionDate, models.SortByProjectName:
+		return fmt.Sprintf(" ORDER BY %s %s, id %s", q.SortBy, direction, direction)
+	case models.SortByProjectLevel:
+		return fmt.Sprintf(" ORDER BY %s %s, id %s", levelOrder, direction, direction)
+	case models.SortByID:
+		return " ORDER BY id " + direction
+	case models.SortByRelevance:
+		// bm25 returns lower values for better matches.
+		return fmt.Sprintf(" ORDER BY relevance %s, id", direction)
+	default:
+		return fmt.Sprintf(" ORDER BY %s %s, submission_date, id", statusOrder, direction)
+	}
+}
+
+// matchExpression turns free text into an FTS5 query matching documents that contain
+// every word of the text as a prefix. It returns an empty string if the text has no words.
+func matchExpression(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, `"`+word+`"*`)
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// applicationColumns lists the application columns in the order scanApplication reads them.
+const applicationColumns = `id,
+		public_id,
+		applicant_name,
+		applicant_email,
+		applicant_phone,
+		position_and_organization,
+		project_duration,
+		project_level,
+		problem_holder,
+		project_goal,
+		barrier,
+		existing_solutions,
+		keywords,
+		interested_parties,
+		consultants,
+		additional_materials,
+		project_name,
+		answers,
+		status,
+		submission_date,
+		revision`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanApplication reads the applicationColumns of the row into application
+// and the columns selected after them into extra.
+func scanApplication(row rowScanner, application *models.Application, extra ...any) error {
+	dest := []any{
+		&application.ID,
+		&application.PublicID,
+		&application.ApplicantName,
+		&application.ApplicantEmail,
+		&application.ApplicantPhone,
+		&application.PositionAndOrganization,
+		&application.ProjectDuration,
+		&application.ProjectLevel,
+		&application.ProblemHolder,
+		&application.ProjectGoal,
+		&application.Barrier,
+		&application.ExistingSolutions,
+		&application.Keywords,
+		&application.InterestedParties,
+		&application.Consultants,
+		&application.AdditionalMaterials,
+		&application.ProjectName,
+		&application.Answers,
+		&application.Status,
+		&application.SubmissionDate,
+		&application.Revision,
+	}
+
+	return row.Scan(append(dest, extra...)...)
+}
@@ -0,0 +1,256 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/storage"
+)
+
+// UpdateApplication replaces the applicant fields of the application and records the edit as a new revision.
+//
+// The update is a compare-and-set: it returns storage.ErrRevisionConflict unless the application
+// is still at the given revision and in the given status, and storage.ErrApplicationNotFound
+// if there is no application with the given ID.
+func (s *Storage) UpdateApplication(ctx context.Context, id int64, revision int, status models.Status, fields models.ApplicationFields, authorType, editedBy string) (err error) {
+	const op = "storage.sqlite.UpdateApplication"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var current models.Application
+
+	err = scanApplication(tx.QueryRowContext(ctx, `SELECT `+applicationColumns+` FROM applications WHERE id = ? AND deleted_at IS NULL`, id), &current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrApplicationNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	if current.Revision != revision || current.Status != status {
+		return storage.ErrRevisionConflict
+	}
+
+	res, err := tx.ExecContext(ctx, `UPDATE applications SET
+		applicant_name = ?,
+		applicant_email = ?,
+		applicant_phone = ?,
+		position_and_organization = ?,
+		project_duration = ?,
+		project_level = ?,
+		problem_holder = ?,
+		project_goal = ?,
+		barrier = ?,
+		existing_solutions = ?,
+		keywords = ?,
+		interested_parties = ?,
+		consultants = ?,
+		additional_materials = ?,
+		project_name = ?,
+		answers = ?,
+		revision = revision + 1
+		WHERE id = ? AND revision = ? AND status = ?`,
+		fields.ApplicantName,
+		fields.ApplicantEmail,
+		fields.ApplicantPhone,
+		fields.PositionAndOrganization,
+		fields.ProjectDuration,
+		fields.ProjectLevel,
+		fields.ProblemHolder,
+		fields.ProjectGoal,
+		fields.Barrier,
+		fields.ExistingSolutions,
+		fields.Keywords,
+		fields.InterestedParties,
+		fields.Consultants,
+		fields.AdditionalMaterials,
+		fields.ProjectName,
+		fields.Answers,
+		id, revision, status,
+	)
+	if err != nil {
+		if constraintErr := constraintError(err); constraintErr != nil {
+			return fmt.Errorf("%s: %w", op, constraintErr)
+		}
+
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get rows affected: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return storage.ErrRevisionConflict
+	}
+
+	if err := syncTags(ctx, tx, id, fields.Keywords); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = recordRevision(ctx, tx, id, revision+1, authorType, editedBy, &current.ApplicationFields, fields)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// recordRevision stores an edit of the application fields, a nil before marks the submitted version.
+func recordRevision(
+	ctx context.Context,
+	tx *sql.Tx,
+	applicationID int64,
+	revision int,
+	authorType,
+	editedBy string,
+	before *models.ApplicationFields,
+	after models.ApplicationFields,
+) error {
+	beforeJSON := []byte("{}")
+	if before != nil {
+		var err error
+		if beforeJSON, err = json.Marshal(before); err != nil {
+			return fmt.Errorf("record revision: %w", err)
+		}
+	}
+
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return fmt.Errorf("record revision: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO application_revisions(
+                         application_id,
+                         revision,
+                         author_type,
+                         edited_by,
+                         before,
+                         after)
+					values(?,?,?,?,?,?)`, applicationID, revision, authorType, editedBy, string(beforeJSON), string(afterJSON))
+	if err != nil {
+		return fmt.Errorf("record revision: %w", err)
+	}
+
+	return nil
+}
+
+// GetApplicationRevisions returns all revisions of the application, the submitted version first.
+//
+// It returns storage.ErrApplicationNotFound if there is no application with the given ID.
+func (s *Storage) GetApplicationRevisions(ctx context.Context, id int64) (_ []models.ApplicationRevision, err error) {
+	const op = "storage.sqlite.GetApplicationRevisions"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+revisionColumns+`
+		FROM application_revisions WHERE application_id = ?
+		ORDER BY revision`, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	revisions := []models.ApplicationRevision{}
+
+	for rows.Next() {
+		var revision models.ApplicationRevision
+		if err := scanRevision(rows, &revision); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	if len(revisions) == 0 {
+		var exists bool
+
+		err = s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM applications WHERE id = ?)`, id).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+		}
+
+		if !exists {
+			return nil, storage.ErrApplicationNotFound
+		}
+	}
+
+	return revisions, nil
+}
+
+// GetApplicationRevision returns the given revision of the application.
+//
+// It returns storage.ErrRevisionNotFound if the application has no such revision.
+func (s *Storage) GetApplicationRevision(ctx context.Context, id int64, revision int) (_ *models.ApplicationRevision, err error) {
+	const op = "storage.sqlite.GetApplicationRevision"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	var result models.ApplicationRevision
+
+	err = scanRevision(s.db.QueryRowContext(ctx, `SELECT `+revisionColumns+`
+		FROM application_revisions WHERE application_id = ? AND revision = ?`, id, revision), &result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &result, nil
+}
+
+const revisionColumns = `id,
+		application_id,
+		revision,
+		author_type,
+		edited_by,
+		before,
+		after,
+		created_at`
+
+// scanRevision scans a row selected with revisionColumns.
+func scanRevision(row rowScanner, revision *models.ApplicationRevision) error {
+	var before, after string
+
+	err := row.Scan(
+		&revision.ID,
+		&revision.ApplicationID,
+		&revision.Revision,
+		&revision.AuthorType,
+		&revision.EditedBy,
+		&before,
+		&after,
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(before), &revision.Before); err != nil {
+		return fmt.Errorf("decode before: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(after), &revision.After); err != nil {
+		return fmt.Errorf("decode after: %w", err)
+	}
+
+	return nil
+}
@@ -1,57 +1,48 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
+	"embed"
 	"errors"
 	"fmt"
+	"io/fs"
 	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/lib/uuid"
 	"projectsShowcase/internal/storage"
+	"projectsShowcase/internal/storage/migrate"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+var tracer = otel.Tracer("projectsShowcase/internal/storage/sqlite")
+
 type Storage struct {
-	db *sql.DB
+	db       *sql.DB
+	observer storage.OpObserver
 }
 
 // New creates a new SQLite storage instance.
 //
 // storagePath is the path to the SQLite database file.
+// The schema is managed by the migrator returned from Storage.Migrator.
 func New(storagePath string) (*Storage, error) {
 	const op = "storage.sqlite.New"
 
-	db, err := sql.Open("sqlite3", storagePath)
+	db, err := sql.Open("sqlite3", storagePath+"?_foreign_keys=on")
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	stmt, err := db.Prepare(`
-    CREATE TABLE IF NOT EXISTS applications (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		applicant_name TEXT NOT NULL,
-		applicant_email TEXT NOT NULL,
-		applicant_phone TEXT NOT NULL,
-		position_and_organization TEXT NOT NULL,
-		project_duration TEXT CHECK(project_duration IN ('1 семестр', '2 семестра')) NOT NULL,
-		project_level TEXT CHECK(project_level IN ('Диагностический проект', 'Учебный проект', 'Учебно-прикладной проект', 'Прикладной проект')) NOT NULL,
-		problem_holder TEXT NOT NULL,
-		project_goal TEXT NOT NULL,
-		barrier TEXT NOT NULL,
-		existing_solutions TEXT NOT NULL,
-		keywords TEXT,
-		interested_parties TEXT,
-		consultants TEXT,
-		additional_materials TEXT,
-		project_name TEXT NOT NULL,
-		status TEXT CHECK(status IN ('На рассмотрении', 'Допущена', 'Удалена')) NOT NULL,
-		submission_date DATETIME DEFAULT CURRENT_TIMESTAMP);
-    `)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
-
-	_, err = stmt.Exec()
-	if err != nil {
+	if err := db.Ping(); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -60,14 +51,20 @@ func New(storagePath string) (*Storage, error) {
 
 // SaveApplication saves an application to the database.
 //
-// The function returns the ID of the inserted application (int64) and an error (error).
+// The function returns the ID (int64) and the public ID (string) of the inserted application and an error (error).
+// A project duration, level or status rejected by the schema is reported as
+// storage.ErrProjectDuration, storage.ErrProjectLevel or storage.ErrProjectStatus.
+// The attachments, already put into the blob store, are linked to the application,
+// the submitted version is recorded as revision 0 and the "application received" notification
+// is queued in the same transaction.
 func (s *Storage) SaveApplication(
+	ctx context.Context,
 	applicantName,
 	applicantEmail,
 	applicantPhone,
-	positionAndOrganization,
-	projectDuration,
-	projectLevel,
+	positionAndOrganization string,
+	projectDuration models.ProjectDuration,
+	projectLevel models.ProjectLevel,
 	problemHolder,
 	projectGoal,
 	barrier,
@@ -76,11 +73,76 @@ func (s *Storage) SaveApplication(
 	interestedParties,
 	consultants,
 	additionalMaterials,
-	projectName,
-	status string) (int64, error) {
+	projectName string,
+	answers models.Answers,
+	status models.Status,
+	attachments []models.Attachment) (_ int64, _ string, err error) {
 	const op = "storage.sqlite.SaveApplication"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
 
-	stmt, err := s.db.Prepare(`INSERT INTO applications(
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	submitted := models.ApplicationFields{
+		ApplicantName:           applicantName,
+		ApplicantEmail:          applicantEmail,
+		ApplicantPhone:          applicantPhone,
+		PositionAndOrganization: positionAndOrganization,
+		ProjectDuration:         projectDuration,
+		ProjectLevel:            projectLevel,
+		ProblemHolder:           problemHolder,
+		ProjectGoal:             projectGoal,
+		Barrier:                 barrier,
+		ExistingSolutions:       existingSolutions,
+		Keywords:                keywords,
+		InterestedParties:       interestedParties,
+		Consultants:             consultants,
+		AdditionalMaterials:     additionalMaterials,
+		ProjectName:             projectName,
+		Answers:                 answers,
+	}
+
+	id, publicID, err := insertApplication(ctx, tx, submitted, status)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := insertAttachments(ctx, tx, id, attachments); err != nil {
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordRevision(ctx, tx, id, 0, models.AuthorApplicant, applicantEmail, nil, submitted); err != nil {
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := enqueueNotification(ctx, tx, id, models.NotificationReceived); err != nil {
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, "", fmt.Errorf("%s: commit transaction: %w", op, err)
+	}
+
+	return id, publicID, nil
+}
+
+// insertApplication inserts an application with a new public ID in the transaction
+// and returns its ID and public ID.
+//
+// A project duration, level or status rejected by the schema is reported as
+// storage.ErrProjectDuration, storage.ErrProjectLevel or storage.ErrProjectStatus.
+func insertApplication(ctx context.Context, tx *sql.Tx, fields models.ApplicationFields, status models.Status) (int64, string, error) {
+	publicID, err := uuid.NewV4()
+	if err != nil {
+		return 0, "", err
+	}
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO applications(
+                         public_id,
                          applicant_name,
                          applicant_email,
                          applicant_phone,
@@ -96,129 +158,65 @@ func (s *Storage) SaveApplication(
                          consultants,
                          additional_materials,
                          project_name,
+                         answers,
                          status)
-					values(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
-	if err != nil {
-		return 0, fmt.Errorf("%s: prepare statement: %w", op, err)
-	}
-
-	res, err := stmt.Exec(
-		applicantName,
-		applicantEmail,
-		applicantPhone,
-		positionAndOrganization,
-		projectDuration,
-		projectLevel,
-		problemHolder,
-		projectGoal,
-		barrier,
-		existingSolutions,
-		keywords,
-		interestedParties,
-		consultants,
-		additionalMaterials,
-		projectName,
+					values(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		publicID,
+		fields.ApplicantName,
+		fields.ApplicantEmail,
+		fields.ApplicantPhone,
+		fields.PositionAndOrganization,
+		fields.ProjectDuration,
+		fields.ProjectLevel,
+		fields.ProblemHolder,
+		fields.ProjectGoal,
+		fields.Barrier,
+		fields.ExistingSolutions,
+		fields.Keywords,
+		fields.InterestedParties,
+		fields.Consultants,
+		fields.AdditionalMaterials,
+		fields.ProjectName,
+		fields.Answers,
 		status,
 	)
 	if err != nil {
-		return 0, fmt.Errorf("%s: execute statement: %w", op, err)
-	}
+		if constraintErr := constraintError(err); constraintErr != nil {
+			return 0, "", constraintErr
+		}
 
-	id, err := res.LastInsertId()
-	if err != nil {
-		return 0, fmt.Errorf("%s: failed to getApproved last insert id: %w", op, err)
+		return 0, "", fmt.Errorf("insert application: %w", err)
 	}
 
-	return id, nil
-}
-
-// GetApplication retrieves an application from the database by its ID.
-func (s *Storage) GetApplication(id int64) (models.Application, error) {
-	const op = "storage.sqlite.GetApplication"
-
-	stmt, err := s.db.Prepare(`SELECT 
-    applicant_name,
-	applicant_email,
-	applicant_phone,
-	position_and_organization,
-	project_duration,
-	project_level,
-	problem_holder,
-	project_goal,
-	barrier,
-	existing_solutions,
-	keywords,
-	interested_parties,
-	consultants,
-	additional_materials,
-	project_name,
-	status
-    FROM applications WHERE id = ?`)
+	id, err := res.LastInsertId()
 	if err != nil {
-		return models.Application{}, fmt.Errorf("%s: prepare statement: %w", op, err)
+		return 0, "", fmt.Errorf("get last insert id: %w", err)
 	}
 
-	var application models.Application
-
-	err = stmt.QueryRow(id).Scan(
-		&application.ApplicantName,
-		&application.ApplicantEmail,
-		&application.ApplicantPhone,
-		&application.PositionAndOrganization,
-		&application.ProjectDuration,
-		&application.ProjectLevel,
-		&application.ProblemHolder,
-		&application.ProjectGoal,
-		&application.Barrier,
-		&application.ExistingSolutions,
-		&application.Keywords,
-		&application.InterestedParties,
-		&application.Consultants,
-		&application.AdditionalMaterials,
-		&application.ProjectName,
-		&application.Status,
-		&application.SubmissionDate,
-	)
-	if errors.Is(err, sql.ErrNoRows) {
-		return models.Application{}, storage.ErrApplicationNotFound
-	}
-	if err != nil {
-		return models.Application{}, fmt.Errorf("%s: execute statement: %w", op, err)
+	if err := syncTags(ctx, tx, id, fields.Keywords); err != nil {
+		return 0, "", fmt.Errorf("sync tags: %w", err)
 	}
 
-	return application, nil
+	return id, publicID, nil
 }
 
 // GetApprovedApplications retrieves a list of approved applications from the database.
-func (s *Storage) GetApprovedApplications() ([]models.Application, error) {
+func (s *Storage) GetApprovedApplications(ctx context.Context) (_ []models.Application, err error) {
 	const op = "storage.sqlite.GetApprovedApplications"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
 
-	stmt, err := s.db.Prepare(`SELECT 
-	id,
-    applicant_name,
-	applicant_email,
-	applicant_phone,
-	position_and_organization,
-	project_duration,
-	project_level,
-	problem_holder,
-	project_goal,
-	barrier,
-	existing_solutions,
-	keywords,
-	interested_parties,
-	consultants,
-	additional_materials,
-	project_name
-    FROM applications WHERE status = ?
+	stmt, err := s.db.PrepareContext(ctx, `SELECT `+applicationColumns+`
+    FROM applications WHERE status = ? AND deleted_at IS NULL
 	ORDER BY submission_date`)
 	if err != nil {
 		return nil, fmt.Errorf("%s: prepare statement: %w", op, err)
 	}
+	defer stmt.Close()
 
 	var applications []models.Application
 
-	rows, err := stmt.Query("Допущена")
+	rows, err := stmt.QueryContext(ctx, models.StatusApproved)
 	if err != nil {
 		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
 	}
@@ -226,24 +224,7 @@ func (s *Storage) GetApprovedApplications() ([]models.Application, error) {
 
 	for rows.Next() {
 		var application models.Application
-		err = rows.Scan(
-			&application.ID,
-			&application.ApplicantName,
-			&application.ApplicantEmail,
-			&application.ApplicantPhone,
-			&application.PositionAndOrganization,
-			&application.ProjectDuration,
-			&application.ProjectLevel,
-			&application.ProblemHolder,
-			&application.ProjectGoal,
-			&application.Barrier,
-			&application.ExistingSolutions,
-			&application.Keywords,
-			&application.InterestedParties,
-			&application.Consultants,
-			&application.AdditionalMaterials,
-			&application.ProjectName,
-		)
+		err = scanApplication(rows, &application)
 		if err != nil {
 			return nil, fmt.Errorf("%s: scan row: %w", op, err)
 		}
@@ -253,105 +234,259 @@ func (s *Storage) GetApprovedApplications() ([]models.Application, error) {
 	return applications, nil
 }
 
-// GetAllApplications retrieves a list of all applications from the database ordered by status and submission date.
-func (s *Storage) GetAllApplications() ([]models.Application, error) {
+// GetAllApplications retrieves a page of applications matching the query
+// together with the total number of matching applications.
+func (s *Storage) GetAllApplications(ctx context.Context, q models.ApplicationQuery) (_ []models.Application, _ int, err error) {
 	const op = "storage.sqlite.GetAllApplications"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	where, args := applicationsWhere(q)
 
-	stmt, err := s.db.Prepare(`SELECT * FROM applications 
-         ORDER BY CASE WHEN status = 'Допущена' THEN 2 WHEN status = 'Удалена' THEN 1 WHEN status = 'На расмотрении' THEN 0 END, submission_date`)
+	var total int
+
+	err = s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM applications`+where, args...).Scan(&total)
 	if err != nil {
-		return nil, fmt.Errorf("%s: prepare statement: %w", op, err)
+		return nil, 0, fmt.Errorf("%s: count applications: %w", op, err)
 	}
 
-	var applications []models.Application
+	query := `SELECT ` + applicationColumns + `
+		FROM applications` + where + applicationsOrderBy(q)
+	if q.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, q.Limit, q.Offset)
+	}
+
+	applications := []models.Application{}
 
-	rows, err := stmt.Query()
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+		return nil, 0, fmt.Errorf("%s: execute statement: %w", op, err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
 		var application models.Application
-		err = rows.Scan(
-			&application.ID,
-			&application.ApplicantName,
-			&application.ApplicantEmail,
-			&application.ApplicantPhone,
-			&application.PositionAndOrganization,
-			&application.ProjectDuration,
-			&application.ProjectLevel,
-			&application.ProblemHolder,
-			&application.ProjectGoal,
-			&application.Barrier,
-			&application.ExistingSolutions,
-			&application.Keywords,
-			&application.InterestedParties,
-			&application.Consultants,
-			&application.AdditionalMaterials,
-			&application.ProjectName,
-			&application.Status,
-			&application.SubmissionDate,
-		)
+		err = scanApplication(rows, &application)
 		if err != nil {
-			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+			return nil, 0, fmt.Errorf("%s: scan row: %w", op, err)
 		}
 		applications = append(applications, application)
 	}
 
-	return applications, nil
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	return applications, total, nil
 }
 
-// UpdateApplicationStatus updates the status of the application in the database.
-func (s *Storage) UpdateApplicationStatus(id int64, status string) error {
-	const op = "storage.sqlite.UpdateApplication"
+// SearchApplications runs a full-text search over the project name, goal, barrier,
+// existing solutions and keywords of the applications matching the filters of the query.
+//
+// It returns a page of hits ordered as requested by the query (usually by relevance)
+// together with the total number of hits, or storage.ErrEmptySearch if q.Search has no words.
+func (s *Storage) SearchApplications(ctx context.Context, q models.ApplicationQuery) (_ []models.SearchHit, _ int, err error) {
+	const op = "storage.sqlite.SearchApplications"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	match := matchExpression(q.Search)
+	if match == "" {
+		return nil, 0, storage.ErrEmptySearch
+	}
+
+	from := ` FROM (
+		SELECT
+			rowid AS fts_id,
+			bm25(applications_fts, 10.0, 5.0, 2.0, 2.0, 8.0) AS relevance,
+			snippet(applications_fts, -1, char(2), char(3), '…', 16) AS fts_snippet
+		FROM applications_fts WHERE applications_fts MATCH ?
+	) AS matches JOIN applications ON applications.id = matches.fts_id`
+
+	where, args := applicationsWhere(q)
+	args = append([]any{match}, args...)
+
+	var total int
+
+	err = s.db.QueryRowContext(ctx, `SELECT COUNT(*)`+from+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: count applications: %w", op, err)
+	}
+
+	query := `SELECT ` + applicationColumns + `, fts_snippet` + from + where + applicationsOrderBy(q)
+	if q.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, q.Limit, q.Offset)
+	}
+
+	hits := []models.SearchHit{}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hit models.SearchHit
+		err = scanApplication(rows, &hit.Application, &hit.Snippet)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		hit.Snippet = storage.Highlight(hit.Snippet)
+		hits = append(hits, hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	return hits, total, nil
+}
 
-	stmt, err := s.db.Prepare(`UPDATE applications SET status = ? WHERE id = ?`)
+// UpdateApplicationStatus moves the application from one status to another
+// and records the change in the status history.
+// The applicant notification for the new status, if any, is queued in the same transaction.
+//
+// It returns storage.ErrApplicationNotFound if there is no application with the given ID
+// and storage.ErrStatusConflict if the current status of the application is not from.
+func (s *Storage) UpdateApplicationStatus(ctx context.Context, id int64, from, to models.Status, changedBy, reason string) (err error) {
+	const op = "storage.sqlite.UpdateApplicationStatus"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("%s: prepare statement: %w", op, err)
+		return fmt.Errorf("%s: begin transaction: %w", op, err)
 	}
+	defer tx.Rollback()
 
-	res, err := stmt.Exec(status, id)
+	res, err := tx.ExecContext(ctx, `UPDATE applications SET status = ? WHERE id = ? AND status = ? AND deleted_at IS NULL`, to, id, from)
 	if err != nil {
 		return fmt.Errorf("%s: execute statement: %w", op, err)
 	}
 
 	rowsAffected, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("%s: failed to getApproved rows affected: %w", op, err)
+		return fmt.Errorf("%s: failed to get rows affected: %w", op, err)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("no application found with ID %d", id)
+		var exists bool
+
+		err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM applications WHERE id = ? AND deleted_at IS NULL)`, id).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("%s: execute statement: %w", op, err)
+		}
+
+		if !exists {
+			return storage.ErrApplicationNotFound
+		}
+
+		return storage.ErrStatusConflict
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO application_status_history(
+                         application_id,
+                         from_status,
+                         to_status,
+                         changed_by,
+                         reason)
+					values(?,?,?,?,?)`, id, from, to, changedBy, reason)
+	if err != nil {
+		return fmt.Errorf("%s: insert history: %w", op, err)
+	}
+
+	if kind, ok := models.StatusNotifications[to]; ok {
+		if err := enqueueNotification(ctx, tx, id, kind); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit transaction: %w", op, err)
 	}
 
 	return nil
 }
 
+// GetApplicationStatusHistory returns the status changes of the application, oldest first.
+//
+// It returns storage.ErrApplicationNotFound if there is no application with the given ID.
+func (s *Storage) GetApplicationStatusHistory(ctx context.Context, id int64) (_ []models.StatusChange, err error) {
+	const op = "storage.sqlite.GetApplicationStatusHistory"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	stmt, err := s.db.PrepareContext(ctx, `SELECT
+		id,
+		application_id,
+		from_status,
+		to_status,
+		changed_by,
+		reason,
+		changed_at
+		FROM application_status_history WHERE application_id = ?
+		ORDER BY changed_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: prepare statement: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	history := []models.StatusChange{}
+
+	for rows.Next() {
+		var change models.StatusChange
+		err = rows.Scan(
+			&change.ID,
+			&change.ApplicationID,
+			&change.FromStatus,
+			&change.ToStatus,
+			&change.ChangedBy,
+			&change.Reason,
+			&change.ChangedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+		history = append(history, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate rows: %w", op, err)
+	}
+
+	if len(history) == 0 {
+		var exists bool
+
+		err = s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM applications WHERE id = ?)`, id).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+		}
+
+		if !exists {
+			return nil, storage.ErrApplicationNotFound
+		}
+	}
+
+	return history, nil
+}
+
 // GetApplicationByID returns the request by its ID
-func (s *Storage) GetApplicationByID(id int64) (*models.Application, error) {
+func (s *Storage) GetApplicationByID(ctx context.Context, id int64) (_ *models.Application, err error) {
 	const op = "storage.sqlite.GetApplicationByID"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
 
-	stmt, err := s.db.Prepare(`SELECT 
-		id,
-		applicant_name,
-		applicant_email,
-		applicant_phone,
-		position_and_organization,
-		project_duration,
-		project_level,
-		problem_holder,
-		project_goal,
-		barrier,
-		existing_solutions,
-		keywords,
-		interested_parties,
-		consultants,
-		additional_materials,
-		project_name,
-		status,
-		submission_date
-		FROM applications WHERE id = ?`)
+	stmt, err := s.db.PrepareContext(ctx, `SELECT `+applicationColumns+`
+		FROM applications WHERE id = ? AND deleted_at IS NULL`)
 	if err != nil {
 		return nil, fmt.Errorf("%s: prepare statement: %w", op, err)
 	}
@@ -359,27 +494,7 @@ func (s *Storage) GetApplicationByID(id int64) (*models.Application, error) {
 
 	var application models.Application
 
-	err = stmt.QueryRow(id).Scan(
-		&application.ID,
-		&application.ApplicantName,
-		&application.ApplicantEmail,
-		&application.ApplicantPhone,
-		&application.PositionAndOrganization,
-		&application.ProjectDuration,
-		&application.ProjectLevel,
-		&application.ProblemHolder,
-		&application.ProjectGoal,
-		&application.Barrier,
-		&application.ExistingSolutions,
-		&application.Keywords,
-		&application.InterestedParties,
-		&application.Consultants,
-		&application.AdditionalMaterials,
-		&application.ProjectName,
-		&application.Status,
-		&application.SubmissionDate,
-	)
-
+	err = scanApplication(stmt.QueryRowContext(ctx, id), &application)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, storage.ErrApplicationNotFound
 	}
@@ -390,28 +505,128 @@ func (s *Storage) GetApplicationByID(id int64) (*models.Application, error) {
 	return &application, nil
 }
 
-// DeleteApplication deletes the request from the database by its ID.
-func (s *Storage) DeleteApplication(id int64) error {
-	const op = "storage.sqlite.DeleteApplication"
+// GetApplicationByPublicID returns the application with the given public ID whatever its status is.
+//
+// It returns storage.ErrApplicationNotFound if there is no such application.
+func (s *Storage) GetApplicationByPublicID(ctx context.Context, publicID string) (_ *models.Application, err error) {
+	const op = "storage.sqlite.GetApplicationByPublicID"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	stmt, err := s.db.PrepareContext(ctx, `SELECT `+applicationColumns+`
+		FROM applications WHERE public_id = ? AND deleted_at IS NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: prepare statement: %w", op, err)
+	}
+	defer stmt.Close()
 
-	stmt, err := s.db.Prepare(`DELETE FROM applications WHERE id = ?`)
+	var application models.Application
+
+	err = scanApplication(stmt.QueryRowContext(ctx, publicID), &application)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrApplicationNotFound
+	}
 	if err != nil {
-		return fmt.Errorf("%s: prepare statement: %w", op, err)
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
 	}
 
-	res, err := stmt.Exec(id)
+	return &application, nil
+}
+
+// GetApprovedApplicationByPublicID returns the approved application with the given public ID.
+//
+// It returns storage.ErrApplicationNotFound if there is no such application or it is not approved.
+func (s *Storage) GetApprovedApplicationByPublicID(ctx context.Context, publicID string) (_ *models.Application, err error) {
+	const op = "storage.sqlite.GetApprovedApplicationByPublicID"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	stmt, err := s.db.PrepareContext(ctx, `SELECT `+applicationColumns+`
+		FROM applications WHERE public_id = ? AND status = ? AND deleted_at IS NULL`)
 	if err != nil {
-		return fmt.Errorf("%s: execute statement: %w", op, err)
+		return nil, fmt.Errorf("%s: prepare statement: %w", op, err)
 	}
+	defer stmt.Close()
 
-	rowsAffected, err := res.RowsAffected()
+	var application models.Application
+
+	err = scanApplication(stmt.QueryRowContext(ctx, publicID, models.StatusApproved), &application)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrApplicationNotFound
+	}
 	if err != nil {
-		return fmt.Errorf("%s: failed to getApproved rows affected: %w", op, err)
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("no application found with ID %d", id)
+	return &application, nil
+}
+
+// Ping checks that the database is reachable.
+func (s *Storage) Ping(ctx context.Context) (err error) {
+	const op = "storage.sqlite.Ping"
+	ctx, end := s.start(ctx, op)
+	defer end(&err)
+
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
+
+// SetOpObserver makes the storage report the duration of every operation to the observer.
+// It has to be called before the storage is used concurrently.
+func (s *Storage) SetOpObserver(observer storage.OpObserver) {
+	s.observer = observer
+}
+
+// start begins the operation op: it opens its span and times it for the op observer.
+// The returned function ends both, it is deferred at the beginning of the operation with a pointer
+// to the named error result, so that a failed operation marks its span as failed.
+func (s *Storage) start(ctx context.Context, op string) (context.Context, func(errp *error)) {
+	ctx, span := tracer.Start(ctx, op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemSqlite),
+	)
+	start := time.Now()
+
+	return ctx, func(errp *error) {
+		if err := *errp; err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+		if s.observer != nil {
+			s.observer.ObserveOp(op, time.Since(start))
+		}
+	}
+}
+
+// Close closes the underlying database connection.
+func (s *Storage) Close() error {
+	return s.db.Close()
+}
+
+// Migrator returns the schema migrator for the database.
+func (s *Storage) Migrator() (*migrate.Migrator, error) {
+	const op = "storage.sqlite.Migrator"
+
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m, err := migrate.New(s.db, migrate.DialectSQLite, fsys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The tags of the applications saved before migration 14 are parsed from their keywords in Go.
+	m.AfterApply(14, func() error {
+		_, err := s.RebuildTags(context.Background())
+		return err
+	})
+
+	return m, nil
+}
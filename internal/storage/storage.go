@@ -1,10 +1,113 @@
 package storage
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"html"
+	"projectsShowcase/internal/domain/models"
+	"projectsShowcase/internal/storage/migrate"
+	"strings"
+	"time"
+)
 
 var (
 	ErrApplicationNotFound = errors.New("application not found")
 	ErrProjectDuration     = errors.New("duration is not valid")
 	ErrProjectLevel        = errors.New("level is not valid")
 	ErrProjectStatus       = errors.New("status is not valid")
+	ErrStatusConflict      = errors.New("application status was changed concurrently")
+	ErrRevisionConflict    = errors.New("application was changed concurrently")
+	ErrRevisionNotFound    = errors.New("application revision not found")
+	ErrEmptySearch         = errors.New("search query is empty")
+	ErrAdminUserNotFound   = errors.New("admin user not found")
+	ErrAdminUserExists     = errors.New("admin user already exists")
+	ErrAdminRole           = errors.New("admin role is not valid")
+	ErrSessionNotFound     = errors.New("admin session not found")
+	ErrAttachmentNotFound  = errors.New("attachment not found")
+	ErrFormFieldNotFound   = errors.New("form field not found")
+	ErrFormFieldExists     = errors.New("form field already exists")
+	ErrTagNotFound         = errors.New("tag not found")
+	ErrTagExists           = errors.New("tag or synonym already exists")
+	ErrTagMergeSelf        = errors.New("tag can't be merged into itself")
+	ErrTagMergeTarget      = errors.New("tag to merge into not found")
+	ErrTagSynonymNotFound  = errors.New("tag synonym not found")
+)
+
+// Markers the backends put around search matches in snippets, replaced by Highlight.
+const (
+	HighlightStart = "\x02"
+	HighlightStop  = "\x03"
 )
+
+// Highlight escapes a snippet returned by the database for HTML
+// and wraps the matches marked with HighlightStart and HighlightStop in <mark> tags.
+func Highlight(snippet string) string {
+	snippet = html.EscapeString(snippet)
+	snippet = strings.ReplaceAll(snippet, HighlightStart, "<mark>")
+
+	return strings.ReplaceAll(snippet, HighlightStop, "</mark>")
+}
+
+// OpObserver records how long storage operations take, op is the name of the operation,
+// e.g. "storage.sqlite.SaveApplication".
+type OpObserver interface {
+	ObserveOp(op string, duration time.Duration)
+}
+
+// Repository is the set of operations every storage backend has to provide.
+//
+// It is the union of the interfaces the HTTP handlers depend on, so any implementation
+// can be passed to them from main, plus the lifecycle methods main itself needs.
+type Repository interface {
+	SaveApplication(ctx context.Context, applicantName, applicantEmail, applicantPhone, positionAndOrganization string, projectDuration models.ProjectDuration, projectLevel models.ProjectLevel, problemHolder, projectGoal, barrier, existingSolutions, keywords, interestedParties, consultants, additionalMaterials, projectName string, answers models.Answers, status models.Status, attachments []models.Attachment) (int64, string, error)
+	ImportApplications(ctx context.Context, applications []models.ApplicationFields, importedBy string) ([]int64, error)
+	GetApplicationByID(ctx context.Context, id int64) (*models.Application, error)
+	GetApplicationByPublicID(ctx context.Context, publicID string) (*models.Application, error)
+	GetApprovedApplicationByPublicID(ctx context.Context, publicID string) (*models.Application, error)
+	GetApprovedApplications(ctx context.Context) ([]models.Application, error)
+	GetAllApplications(ctx context.Context, q models.ApplicationQuery) ([]models.Application, int, error)
+	ExportApplications(ctx context.Context, q models.ApplicationQuery, fn func(models.Application) error) error
+	SearchApplications(ctx context.Context, q models.ApplicationQuery) ([]models.SearchHit, int, error)
+	UpdateApplicationStatus(ctx context.Context, id int64, from, to models.Status, changedBy, reason string) error
+	UpdateApplication(ctx context.Context, id int64, revision int, status models.Status, fields models.ApplicationFields, authorType, editedBy string) error
+	GetApplicationRevisions(ctx context.Context, id int64) ([]models.ApplicationRevision, error)
+	GetApplicationRevision(ctx context.Context, id int64, revision int) (*models.ApplicationRevision, error)
+	GetApplicationStatusHistory(ctx context.Context, id int64) ([]models.StatusChange, error)
+	DeleteApplication(ctx context.Context, id int64, deletedBy string) error
+	RestoreApplication(ctx context.Context, id int64) error
+	GetDeletedApplications(ctx context.Context, limit, offset int) ([]models.DeletedApplication, int, error)
+	PurgeDeletedApplications(ctx context.Context, deletedBefore time.Time) (int64, error)
+	GetApplicationAttachments(ctx context.Context, applicationID int64) ([]models.Attachment, error)
+	GetAttachment(ctx context.Context, applicationID, attachmentID int64) (*models.Attachment, error)
+	MarkBlobsOrphaned(ctx context.Context, blobKeys []string) error
+	GetOrphanedBlobs(ctx context.Context, markedBefore time.Time) ([]string, error)
+	ClaimOrphanedBlob(ctx context.Context, blobKey string, markedBefore time.Time) (bool, error)
+	GetFormFields(ctx context.Context) ([]models.FormField, error)
+	GetFormField(ctx context.Context, id int64) (*models.FormField, error)
+	SaveFormField(ctx context.Context, field models.FormField) (int64, error)
+	UpdateFormField(ctx context.Context, field models.FormField) error
+	GetApprovedTags(ctx context.Context) ([]models.Tag, error)
+	GetTags(ctx context.Context) ([]models.Tag, error)
+	RenameTag(ctx context.Context, id int64, name string) error
+	MergeTags(ctx context.Context, sourceID, targetID int64) error
+	AddTagSynonym(ctx context.Context, id int64, name string) error
+	DeleteTagSynonym(ctx context.Context, id int64, name string) error
+	RebuildTags(ctx context.Context) (int, error)
+	CountApplicationsByStatus(ctx context.Context) (map[models.Status]int, error)
+	SaveAdminUser(ctx context.Context, login, passwordHash, role string) (int64, error)
+	GetAdminUserByLogin(ctx context.Context, login string) (*models.AdminUser, error)
+	UpdateAdminUserPassword(ctx context.Context, login, passwordHash string) error
+	CountAdminUsers(ctx context.Context) (int, error)
+	SaveAdminSession(ctx context.Context, tokenHash string, adminUserID int64, expiresAt time.Time) error
+	GetAdminSession(ctx context.Context, tokenHash string) (*models.AdminSession, error)
+	DeleteAdminSession(ctx context.Context, tokenHash string) error
+	DeleteExpiredAdminSessions(ctx context.Context, now time.Time) error
+	GetPendingNotifications(ctx context.Context, now time.Time, limit int) ([]models.Notification, error)
+	MarkNotificationSent(ctx context.Context, id int64) error
+	RetryNotification(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+	FailNotification(ctx context.Context, id int64, lastError string) error
+	Ping(ctx context.Context) error
+	SetOpObserver(observer OpObserver)
+	Migrator() (*migrate.Migrator, error)
+	Close() error
+}
@@ -0,0 +1,147 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	tracingMiddleware "projectsShowcase/internal/http-server/middleware/tracing"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// exportedSpan is the part of a span printed by the stdout exporter the tests look at.
+type exportedSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+	}
+	Parent struct {
+		SpanID string
+	}
+	Attributes []attribute
+	Resource   []attribute
+}
+
+type attribute struct {
+	Key   string
+	Value struct {
+		Value any
+	}
+}
+
+func (s exportedSpan) attr(key string) any {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			return a.Value.Value
+		}
+	}
+
+	return nil
+}
+
+// setupStdout installs the stdout exporter printing into a buffer, the spans are in it once shutdown returns.
+func setupStdout(t *testing.T) (*bytes.Buffer, func(context.Context) error) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	prev := stdout
+	stdout = &buf
+	t.Cleanup(func() { stdout = prev })
+
+	shutdown, err := Setup(context.Background(), Options{
+		Exporter:    ExporterStdout,
+		SampleRatio: 1,
+		ServiceName: "projects-showcase-test",
+	})
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	return &buf, shutdown
+}
+
+func decodeSpans(t *testing.T, r io.Reader) []exportedSpan {
+	t.Helper()
+
+	var spans []exportedSpan
+	dec := json.NewDecoder(r)
+	for {
+		var span exportedSpan
+		err := dec.Decode(&span)
+		if errors.Is(err, io.EOF) {
+			return spans
+		}
+		if err != nil {
+			t.Fatalf("decode exported span: %v", err)
+		}
+
+		spans = append(spans, span)
+	}
+}
+
+func TestSetupUnknownExporter(t *testing.T) {
+	if _, err := Setup(context.Background(), Options{Exporter: "jaeger"}); err == nil {
+		t.Fatal("Setup succeeded for an unknown exporter")
+	}
+}
+
+func TestStdoutExportsRequestSpans(t *testing.T) {
+	buf, shutdown := setupStdout(t)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	router := chi.NewRouter()
+	router.Use(tracingMiddleware.New(log, "/healthz"))
+	router.Get("/applications/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	router.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/applications/42", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	spans := decodeSpans(t, buf)
+	if len(spans) != 1 {
+		t.Fatalf("exported %d spans, want 1 (the probe is not traced)", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name != "GET /applications/{id}" {
+		t.Errorf("span name = %q, want %q", span.Name, "GET /applications/{id}")
+	}
+
+	if got := span.attr("http.route"); got != "/applications/{id}" {
+		t.Errorf("http.route = %v, want /applications/{id}", got)
+	}
+
+	if span.SpanContext.TraceID != traceID || span.Parent.SpanID != parentID {
+		t.Errorf("span is in trace %s under %s, want the caller's trace %s under %s",
+			span.SpanContext.TraceID, span.Parent.SpanID, traceID, parentID)
+	}
+
+	var service any
+	for _, a := range span.Resource {
+		if a.Key == "service.name" {
+			service = a.Value.Value
+		}
+	}
+	if service != "projects-showcase-test" {
+		t.Errorf("service.name = %v, want projects-showcase-test", service)
+	}
+}
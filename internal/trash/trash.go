@@ -0,0 +1,64 @@
+package trash
+
+import (
+	"context"
+	"log/slog"
+	"projectsShowcase/internal/lib/logger/sl"
+	"time"
+)
+
+type Repository interface {
+	PurgeDeletedApplications(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+// Purger deletes the applications that have been in the trash longer than the retention period for good.
+// The files attached to them are left to the blob collector, see blobgc.Collector.
+type Purger struct {
+	log       *slog.Logger
+	repo      Repository
+	retention time.Duration
+	interval  time.Duration
+}
+
+func New(log *slog.Logger, repo Repository, retention, interval time.Duration) *Purger {
+	return &Purger{
+		log:       log.With(slog.String("component", "trash")),
+		repo:      repo,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Run purges the trash every interval until ctx is done.
+func (p *Purger) Run(ctx context.Context) {
+	p.log.Info("trash purger started",
+		slog.String("retention", p.retention.String()),
+		slog.String("interval", p.interval.String()),
+	)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			p.log.Info("trash purger stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Purger) purge(ctx context.Context) {
+	purged, err := p.repo.PurgeDeletedApplications(ctx, time.Now().Add(-p.retention))
+	if err != nil {
+		p.log.Error("failed to purge the trash", sl.Err(err))
+		return
+	}
+
+	if purged > 0 {
+		p.log.Info("trash purged", slog.Int64("applications", purged))
+	}
+}